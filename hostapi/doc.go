@@ -0,0 +1,16 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hostapi contains the protobuf messages used between the Wasm
+// chaincode host and Wasm guests, in addition to the ledger and contract
+// messages in github.com/hyperledgendary/fabric-ledger-protos-go
+//
+// To regenerate host_messages.pb.go after changing host_messages.proto, run
+// the following command from this directory with protoc-gen-go v1.25.0, where
+// FABRIC_LEDGER_PROTOS is a checkout of the fabric-ledger-protos repository:
+//
+//	protoc -I . -I ${FABRIC_LEDGER_PROTOS} --go_out=. --go_opt=paths=source_relative \
+//	  --go_opt=Mcommon_messages.proto=github.com/hyperledgendary/fabric-ledger-protos-go/contract \
+//	  --go_opt=Mledger_messages.proto=github.com/hyperledgendary/fabric-ledger-protos-go/contract \
+//	  host_messages.proto
+package hostapi
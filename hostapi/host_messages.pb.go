@@ -0,0 +1,3231 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.5.1
+// source: host_messages.proto
+
+package hostapi
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// LogLevel is the level of a guest log message
+type LogLevel int32
+
+const (
+	LogLevel_INFO  LogLevel = 0
+	LogLevel_DEBUG LogLevel = 1
+	LogLevel_WARN  LogLevel = 2
+	LogLevel_ERROR LogLevel = 3
+)
+
+// Enum value maps for LogLevel.
+var (
+	LogLevel_name = map[int32]string{
+		0: "INFO",
+		1: "DEBUG",
+		2: "WARN",
+		3: "ERROR",
+	}
+	LogLevel_value = map[string]int32{
+		"INFO":  0,
+		"DEBUG": 1,
+		"WARN":  2,
+		"ERROR": 3,
+	}
+)
+
+func (x LogLevel) Enum() *LogLevel {
+	p := new(LogLevel)
+	*p = x
+	return p
+}
+
+func (x LogLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_host_messages_proto_enumTypes[0].Descriptor()
+}
+
+func (LogLevel) Type() protoreflect.EnumType {
+	return &file_host_messages_proto_enumTypes[0]
+}
+
+func (x LogLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogLevel.Descriptor instead.
+func (LogLevel) EnumDescriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{0}
+}
+
+// ErrorCode identifies the reason a host call failed
+type ErrorCode int32
+
+const (
+	ErrorCode_UNKNOWN ErrorCode = 0
+	// The request payload could not be decoded
+	ErrorCode_INVALID_REQUEST ErrorCode = 1
+	// The operation, or an option in the request, is not supported
+	ErrorCode_NOT_SUPPORTED ErrorCode = 2
+	// The transaction context does not identify an active transaction
+	ErrorCode_INVALID_CONTEXT ErrorCode = 3
+	// The state does not exist
+	ErrorCode_NOT_FOUND ErrorCode = 4
+	// The state already exists
+	ErrorCode_ALREADY_EXISTS ErrorCode = 5
+	// The operation is not allowed in the current transaction, for example a
+	// write in a read-only transaction
+	ErrorCode_PERMISSION_DENIED ErrorCode = 6
+	// The peer returned an error, or could not be reached
+	ErrorCode_PEER_ERROR ErrorCode = 7
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "INVALID_REQUEST",
+		2: "NOT_SUPPORTED",
+		3: "INVALID_CONTEXT",
+		4: "NOT_FOUND",
+		5: "ALREADY_EXISTS",
+		6: "PERMISSION_DENIED",
+		7: "PEER_ERROR",
+	}
+	ErrorCode_value = map[string]int32{
+		"UNKNOWN":           0,
+		"INVALID_REQUEST":   1,
+		"NOT_SUPPORTED":     2,
+		"INVALID_CONTEXT":   3,
+		"NOT_FOUND":         4,
+		"ALREADY_EXISTS":    5,
+		"PERMISSION_DENIED": 6,
+		"PEER_ERROR":        7,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_host_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_host_messages_proto_enumTypes[1]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{1}
+}
+
+// HandshakeRequest is sent to the guest Handshake operation when the Wasm
+// module is loaded, before any transactions are invoked
+type HandshakeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The host call ABI version implemented by the host
+	AbiVersion uint32 `protobuf:"varint,1,opt,name=abi_version,json=abiVersion,proto3" json:"abi_version,omitempty"`
+	// The optional capabilities supported by the host
+	Capabilities []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (x *HandshakeRequest) Reset() {
+	*x = HandshakeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandshakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandshakeRequest) ProtoMessage() {}
+
+func (x *HandshakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandshakeRequest.ProtoReflect.Descriptor instead.
+func (*HandshakeRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HandshakeRequest) GetAbiVersion() uint32 {
+	if x != nil {
+		return x.AbiVersion
+	}
+	return 0
+}
+
+func (x *HandshakeRequest) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// HandshakeResponse is returned by the guest Handshake operation
+type HandshakeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The host call ABI version the guest was built for
+	AbiVersion uint32 `protobuf:"varint,1,opt,name=abi_version,json=abiVersion,proto3" json:"abi_version,omitempty"`
+	// The optional host capabilities the guest would like to use
+	Capabilities []string `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// The waPC operations exported by the guest
+	Operations []string `protobuf:"bytes,3,rep,name=operations,proto3" json:"operations,omitempty"`
+}
+
+func (x *HandshakeResponse) Reset() {
+	*x = HandshakeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HandshakeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HandshakeResponse) ProtoMessage() {}
+
+func (x *HandshakeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HandshakeResponse.ProtoReflect.Descriptor instead.
+func (*HandshakeResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HandshakeResponse) GetAbiVersion() uint32 {
+	if x != nil {
+		return x.AbiVersion
+	}
+	return 0
+}
+
+func (x *HandshakeResponse) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *HandshakeResponse) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+// LogRequest is sent by the guest to the LogService Log host call, when the
+// Logging capability has been negotiated
+type LogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The transaction context the message was logged in, if any
+	Context *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	Level   LogLevel                     `protobuf:"varint,2,opt,name=level,proto3,enum=hostapi.LogLevel" json:"level,omitempty"`
+	Message string                       `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *LogRequest) Reset() {
+	*x = LogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRequest) ProtoMessage() {}
+
+func (x *LogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogRequest.ProtoReflect.Descriptor instead.
+func (*LogRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LogRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *LogRequest) GetLevel() LogLevel {
+	if x != nil {
+		return x.Level
+	}
+	return LogLevel_INFO
+}
+
+func (x *LogRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetWriteSetRequest is sent by the guest to the LedgerService GetWriteSet
+// host call, when the ReadYourWrites capability has been negotiated
+type GetWriteSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+}
+
+func (x *GetWriteSetRequest) Reset() {
+	*x = GetWriteSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWriteSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWriteSetRequest) ProtoMessage() {}
+
+func (x *GetWriteSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWriteSetRequest.ProtoReflect.Descriptor instead.
+func (*GetWriteSetRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWriteSetRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// WriteSetEntry is a pending write or delete in the current transaction
+type WriteSetEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The private data collection name, or empty for the world state
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key        string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value      []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted    bool   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (x *WriteSetEntry) Reset() {
+	*x = WriteSetEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteSetEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteSetEntry) ProtoMessage() {}
+
+func (x *WriteSetEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteSetEntry.ProtoReflect.Descriptor instead.
+func (*WriteSetEntry) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WriteSetEntry) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *WriteSetEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *WriteSetEntry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *WriteSetEntry) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// GetWriteSetResponse lists the pending writes and deletes in the current
+// transaction, in the order the keys were first written
+type GetWriteSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*WriteSetEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *GetWriteSetResponse) Reset() {
+	*x = GetWriteSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetWriteSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWriteSetResponse) ProtoMessage() {}
+
+func (x *GetWriteSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWriteSetResponse.ProtoReflect.Descriptor instead.
+func (*GetWriteSetResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWriteSetResponse) GetEntries() []*WriteSetEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// BatchRequest is sent by the guest to the LedgerService BatchRequest host
+// call, to execute several ledger operations in a single host call
+type BatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ledger operations, which are executed in order
+	Items []*BatchItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Stop executing operations after the first one which fails
+	StopOnError bool `protobuf:"varint,2,opt,name=stop_on_error,json=stopOnError,proto3" json:"stop_on_error,omitempty"`
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchRequest) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchRequest) GetStopOnError() bool {
+	if x != nil {
+		return x.StopOnError
+	}
+	return false
+}
+
+// BatchItem is a single ledger operation in a batch
+type BatchItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*BatchItem_Create
+	//	*BatchItem_Read
+	//	*BatchItem_Update
+	//	*BatchItem_Delete
+	//	*BatchItem_Exists
+	//	*BatchItem_ReadOptional
+	//	*BatchItem_Put
+	Request isBatchItem_Request `protobuf_oneof:"request"`
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *BatchItem) GetRequest() isBatchItem_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *BatchItem) GetCreate() *contract.CreateStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Create); ok {
+		return x.Create
+	}
+	return nil
+}
+
+func (x *BatchItem) GetRead() *contract.ReadStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Read); ok {
+		return x.Read
+	}
+	return nil
+}
+
+func (x *BatchItem) GetUpdate() *contract.UpdateStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *BatchItem) GetDelete() *contract.DeleteStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+func (x *BatchItem) GetExists() *contract.ExistsStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Exists); ok {
+		return x.Exists
+	}
+	return nil
+}
+
+func (x *BatchItem) GetReadOptional() *contract.ReadStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_ReadOptional); ok {
+		return x.ReadOptional
+	}
+	return nil
+}
+
+func (x *BatchItem) GetPut() *PutStateRequest {
+	if x, ok := x.GetRequest().(*BatchItem_Put); ok {
+		return x.Put
+	}
+	return nil
+}
+
+type isBatchItem_Request interface {
+	isBatchItem_Request()
+}
+
+type BatchItem_Create struct {
+	Create *contract.CreateStateRequest `protobuf:"bytes,1,opt,name=create,proto3,oneof"`
+}
+
+type BatchItem_Read struct {
+	Read *contract.ReadStateRequest `protobuf:"bytes,2,opt,name=read,proto3,oneof"`
+}
+
+type BatchItem_Update struct {
+	Update *contract.UpdateStateRequest `protobuf:"bytes,3,opt,name=update,proto3,oneof"`
+}
+
+type BatchItem_Delete struct {
+	Delete *contract.DeleteStateRequest `protobuf:"bytes,4,opt,name=delete,proto3,oneof"`
+}
+
+type BatchItem_Exists struct {
+	Exists *contract.ExistsStateRequest `protobuf:"bytes,5,opt,name=exists,proto3,oneof"`
+}
+
+type BatchItem_ReadOptional struct {
+	ReadOptional *contract.ReadStateRequest `protobuf:"bytes,6,opt,name=read_optional,json=readOptional,proto3,oneof"`
+}
+
+type BatchItem_Put struct {
+	Put *PutStateRequest `protobuf:"bytes,7,opt,name=put,proto3,oneof"`
+}
+
+func (*BatchItem_Create) isBatchItem_Request() {}
+
+func (*BatchItem_Read) isBatchItem_Request() {}
+
+func (*BatchItem_Update) isBatchItem_Request() {}
+
+func (*BatchItem_Delete) isBatchItem_Request() {}
+
+func (*BatchItem_Exists) isBatchItem_Request() {}
+
+func (*BatchItem_ReadOptional) isBatchItem_Request() {}
+
+func (*BatchItem_Put) isBatchItem_Request() {}
+
+// BatchResult is the outcome of a single ledger operation in a batch, which
+// contains either the operation response or an error message
+type BatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*BatchResult_Create
+	//	*BatchResult_Read
+	//	*BatchResult_Update
+	//	*BatchResult_Delete
+	//	*BatchResult_Exists
+	//	*BatchResult_ReadOptional
+	//	*BatchResult_Put
+	Result isBatchResult_Result `protobuf_oneof:"result"`
+	Error  string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// The error code, if the operation failed
+	ErrorCode ErrorCode `protobuf:"varint,7,opt,name=error_code,json=errorCode,proto3,enum=hostapi.ErrorCode" json:"error_code,omitempty"`
+}
+
+func (x *BatchResult) Reset() {
+	*x = BatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResult) ProtoMessage() {}
+
+func (x *BatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResult.ProtoReflect.Descriptor instead.
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *BatchResult) GetResult() isBatchResult_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *BatchResult) GetCreate() *contract.CreateStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Create); ok {
+		return x.Create
+	}
+	return nil
+}
+
+func (x *BatchResult) GetRead() *contract.ReadStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Read); ok {
+		return x.Read
+	}
+	return nil
+}
+
+func (x *BatchResult) GetUpdate() *contract.UpdateStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Update); ok {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *BatchResult) GetDelete() *contract.DeleteStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+func (x *BatchResult) GetExists() *contract.ExistsStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Exists); ok {
+		return x.Exists
+	}
+	return nil
+}
+
+func (x *BatchResult) GetReadOptional() *ReadOptionalStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_ReadOptional); ok {
+		return x.ReadOptional
+	}
+	return nil
+}
+
+func (x *BatchResult) GetPut() *PutStateResponse {
+	if x, ok := x.GetResult().(*BatchResult_Put); ok {
+		return x.Put
+	}
+	return nil
+}
+
+func (x *BatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchResult) GetErrorCode() ErrorCode {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ErrorCode_UNKNOWN
+}
+
+type isBatchResult_Result interface {
+	isBatchResult_Result()
+}
+
+type BatchResult_Create struct {
+	Create *contract.CreateStateResponse `protobuf:"bytes,1,opt,name=create,proto3,oneof"`
+}
+
+type BatchResult_Read struct {
+	Read *contract.ReadStateResponse `protobuf:"bytes,2,opt,name=read,proto3,oneof"`
+}
+
+type BatchResult_Update struct {
+	Update *contract.UpdateStateResponse `protobuf:"bytes,3,opt,name=update,proto3,oneof"`
+}
+
+type BatchResult_Delete struct {
+	Delete *contract.DeleteStateResponse `protobuf:"bytes,4,opt,name=delete,proto3,oneof"`
+}
+
+type BatchResult_Exists struct {
+	Exists *contract.ExistsStateResponse `protobuf:"bytes,5,opt,name=exists,proto3,oneof"`
+}
+
+type BatchResult_ReadOptional struct {
+	ReadOptional *ReadOptionalStateResponse `protobuf:"bytes,8,opt,name=read_optional,json=readOptional,proto3,oneof"`
+}
+
+type BatchResult_Put struct {
+	Put *PutStateResponse `protobuf:"bytes,9,opt,name=put,proto3,oneof"`
+}
+
+func (*BatchResult_Create) isBatchResult_Result() {}
+
+func (*BatchResult_Read) isBatchResult_Result() {}
+
+func (*BatchResult_Update) isBatchResult_Result() {}
+
+func (*BatchResult_Delete) isBatchResult_Result() {}
+
+func (*BatchResult_Exists) isBatchResult_Result() {}
+
+func (*BatchResult_ReadOptional) isBatchResult_Result() {}
+
+func (*BatchResult_Put) isBatchResult_Result() {}
+
+// BatchResponse contains a result for each operation which was executed, in
+// the same order as the batch request items
+type BatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*BatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BatchResponse) Reset() {
+	*x = BatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchResponse) ProtoMessage() {}
+
+func (x *BatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchResponse) GetResults() []*BatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// GetMultipleStatesRequest is sent by the guest to the LedgerService
+// GetMultipleStates host call, to read several states in a single host call.
+// The host still reads the states from the peer one at a time
+type GetMultipleStatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context   *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	StateKeys []string                     `protobuf:"bytes,2,rep,name=state_keys,json=stateKeys,proto3" json:"state_keys,omitempty"`
+	// The private data collection to read from, or empty for the world state
+	Collection *contract.Collection `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *GetMultipleStatesRequest) Reset() {
+	*x = GetMultipleStatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMultipleStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipleStatesRequest) ProtoMessage() {}
+
+func (x *GetMultipleStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipleStatesRequest.ProtoReflect.Descriptor instead.
+func (*GetMultipleStatesRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMultipleStatesRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *GetMultipleStatesRequest) GetStateKeys() []string {
+	if x != nil {
+		return x.StateKeys
+	}
+	return nil
+}
+
+func (x *GetMultipleStatesRequest) GetCollection() *contract.Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+// GetMultipleStatesResponse contains the states which exist, in the same
+// order as the requested keys; keys which have no state are omitted
+type GetMultipleStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*contract.State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *GetMultipleStatesResponse) Reset() {
+	*x = GetMultipleStatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMultipleStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipleStatesResponse) ProtoMessage() {}
+
+func (x *GetMultipleStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipleStatesResponse.ProtoReflect.Descriptor instead.
+func (*GetMultipleStatesResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetMultipleStatesResponse) GetStates() []*contract.State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+// HostError is returned through the waPC host error, instead of an error
+// message, when the ErrorCodes capability has been negotiated. It is encoded
+// with the same binding as the host call
+type HostError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    ErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=hostapi.ErrorCode" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The state key the operation failed for, if any
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// The private data collection the operation failed for, if any
+	Collection string `protobuf:"bytes,4,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *HostError) Reset() {
+	*x = HostError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostError) ProtoMessage() {}
+
+func (x *HostError) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostError.ProtoReflect.Descriptor instead.
+func (*HostError) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HostError) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_UNKNOWN
+}
+
+func (x *HostError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HostError) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *HostError) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+// ReadOptionalStateResponse is returned by the LedgerService ReadOptionalState
+// host call, which takes a ReadStateRequest like ReadState, but does not fail
+// if the state does not exist
+type ReadOptionalStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The state, if it exists
+	State  *contract.State `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Exists bool            `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+}
+
+func (x *ReadOptionalStateResponse) Reset() {
+	*x = ReadOptionalStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadOptionalStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadOptionalStateResponse) ProtoMessage() {}
+
+func (x *ReadOptionalStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadOptionalStateResponse.ProtoReflect.Descriptor instead.
+func (*ReadOptionalStateResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReadOptionalStateResponse) GetState() *contract.State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ReadOptionalStateResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+// PutStateRequest is sent by the guest to the LedgerService PutState host
+// call, to write a state whether or not it already exists, without reading it
+// first
+type PutStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	State   *contract.State              `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	// The private data collection to write to, or empty for the world state
+	Collection *contract.Collection `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *PutStateRequest) Reset() {
+	*x = PutStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutStateRequest) ProtoMessage() {}
+
+func (x *PutStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutStateRequest.ProtoReflect.Descriptor instead.
+func (*PutStateRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PutStateRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PutStateRequest) GetState() *contract.State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *PutStateRequest) GetCollection() *contract.Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+// PutStateResponse is returned by the LedgerService PutState host call
+type PutStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PutStateResponse) Reset() {
+	*x = PutStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutStateResponse) ProtoMessage() {}
+
+func (x *PutStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutStateResponse.ProtoReflect.Descriptor instead.
+func (*PutStateResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{15}
+}
+
+// PurgeStateRequest is sent by the guest to the LedgerService PurgeState host
+// call, to purge a private data state and its history, if the peer supports it
+type PurgeStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context  *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	StateKey string                       `protobuf:"bytes,2,opt,name=state_key,json=stateKey,proto3" json:"state_key,omitempty"`
+	// The private data collection to purge from, which must be specified
+	Collection *contract.Collection `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *PurgeStateRequest) Reset() {
+	*x = PurgeStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeStateRequest) ProtoMessage() {}
+
+func (x *PurgeStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeStateRequest.ProtoReflect.Descriptor instead.
+func (*PurgeStateRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurgeStateRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *PurgeStateRequest) GetStateKey() string {
+	if x != nil {
+		return x.StateKey
+	}
+	return ""
+}
+
+func (x *PurgeStateRequest) GetCollection() *contract.Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+// PurgeStateResponse is returned by the LedgerService PurgeState host call
+type PurgeStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PurgeStateResponse) Reset() {
+	*x = PurgeStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeStateResponse) ProtoMessage() {}
+
+func (x *PurgeStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeStateResponse.ProtoReflect.Descriptor instead.
+func (*PurgeStateResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{17}
+}
+
+// GetTransientRequest is sent by the guest to the LedgerService GetTransient
+// host call, to read a single entry from the transient data map
+type GetTransientRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	Key     string                       `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *GetTransientRequest) Reset() {
+	*x = GetTransientRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransientRequest) ProtoMessage() {}
+
+func (x *GetTransientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransientRequest.ProtoReflect.Descriptor instead.
+func (*GetTransientRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetTransientRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *GetTransientRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// GetTransientResponse contains a transient data entry, if it exists
+type GetTransientResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value  []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Exists bool   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+}
+
+func (x *GetTransientResponse) Reset() {
+	*x = GetTransientResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransientResponse) ProtoMessage() {}
+
+func (x *GetTransientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransientResponse.ProtoReflect.Descriptor instead.
+func (*GetTransientResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetTransientResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *GetTransientResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+// GetMSPIDRequest is sent by the guest to the LedgerService GetMSPID host
+// call, to find the MSP ID of the peer organisation
+type GetMSPIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+}
+
+func (x *GetMSPIDRequest) Reset() {
+	*x = GetMSPIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMSPIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMSPIDRequest) ProtoMessage() {}
+
+func (x *GetMSPIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMSPIDRequest.ProtoReflect.Descriptor instead.
+func (*GetMSPIDRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetMSPIDRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+// GetMSPIDResponse contains the MSP ID of the peer organisation, and the name
+// of its implicit private data collection
+type GetMSPIDResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MspId              string `protobuf:"bytes,1,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	ImplicitCollection string `protobuf:"bytes,2,opt,name=implicit_collection,json=implicitCollection,proto3" json:"implicit_collection,omitempty"`
+}
+
+func (x *GetMSPIDResponse) Reset() {
+	*x = GetMSPIDResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMSPIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMSPIDResponse) ProtoMessage() {}
+
+func (x *GetMSPIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMSPIDResponse.ProtoReflect.Descriptor instead.
+func (*GetMSPIDResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetMSPIDResponse) GetMspId() string {
+	if x != nil {
+		return x.MspId
+	}
+	return ""
+}
+
+func (x *GetMSPIDResponse) GetImplicitCollection() string {
+	if x != nil {
+		return x.ImplicitCollection
+	}
+	return ""
+}
+
+// CollectionConfig is the configuration of a private data collection, from the
+// collections configuration file supplied to the host
+type CollectionConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The collection member policy, e.g. OR('Org1MSP.member','Org2MSP.member')
+	Policy string `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// The MSP IDs of the member organisations in the collection member policy
+	MemberOrgs        []string `protobuf:"bytes,3,rep,name=member_orgs,json=memberOrgs,proto3" json:"member_orgs,omitempty"`
+	RequiredPeerCount uint32   `protobuf:"varint,4,opt,name=required_peer_count,json=requiredPeerCount,proto3" json:"required_peer_count,omitempty"`
+	MaxPeerCount      uint32   `protobuf:"varint,5,opt,name=max_peer_count,json=maxPeerCount,proto3" json:"max_peer_count,omitempty"`
+	BlockToLive       uint64   `protobuf:"varint,6,opt,name=block_to_live,json=blockToLive,proto3" json:"block_to_live,omitempty"`
+	MemberOnlyRead    bool     `protobuf:"varint,7,opt,name=member_only_read,json=memberOnlyRead,proto3" json:"member_only_read,omitempty"`
+	MemberOnlyWrite   bool     `protobuf:"varint,8,opt,name=member_only_write,json=memberOnlyWrite,proto3" json:"member_only_write,omitempty"`
+	// The collection level endorsement policy, if any, as a signature policy
+	// or a channel config policy reference
+	EndorsementSignaturePolicy     string `protobuf:"bytes,9,opt,name=endorsement_signature_policy,json=endorsementSignaturePolicy,proto3" json:"endorsement_signature_policy,omitempty"`
+	EndorsementChannelConfigPolicy string `protobuf:"bytes,10,opt,name=endorsement_channel_config_policy,json=endorsementChannelConfigPolicy,proto3" json:"endorsement_channel_config_policy,omitempty"`
+}
+
+func (x *CollectionConfig) Reset() {
+	*x = CollectionConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionConfig) ProtoMessage() {}
+
+func (x *CollectionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionConfig.ProtoReflect.Descriptor instead.
+func (*CollectionConfig) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CollectionConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CollectionConfig) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *CollectionConfig) GetMemberOrgs() []string {
+	if x != nil {
+		return x.MemberOrgs
+	}
+	return nil
+}
+
+func (x *CollectionConfig) GetRequiredPeerCount() uint32 {
+	if x != nil {
+		return x.RequiredPeerCount
+	}
+	return 0
+}
+
+func (x *CollectionConfig) GetMaxPeerCount() uint32 {
+	if x != nil {
+		return x.MaxPeerCount
+	}
+	return 0
+}
+
+func (x *CollectionConfig) GetBlockToLive() uint64 {
+	if x != nil {
+		return x.BlockToLive
+	}
+	return 0
+}
+
+func (x *CollectionConfig) GetMemberOnlyRead() bool {
+	if x != nil {
+		return x.MemberOnlyRead
+	}
+	return false
+}
+
+func (x *CollectionConfig) GetMemberOnlyWrite() bool {
+	if x != nil {
+		return x.MemberOnlyWrite
+	}
+	return false
+}
+
+func (x *CollectionConfig) GetEndorsementSignaturePolicy() string {
+	if x != nil {
+		return x.EndorsementSignaturePolicy
+	}
+	return ""
+}
+
+func (x *CollectionConfig) GetEndorsementChannelConfigPolicy() string {
+	if x != nil {
+		return x.EndorsementChannelConfigPolicy
+	}
+	return ""
+}
+
+// GetCollectionConfigRequest is sent by the guest to the LedgerService
+// GetCollectionConfig host call
+type GetCollectionConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context    *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	Collection *contract.Collection         `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+}
+
+func (x *GetCollectionConfigRequest) Reset() {
+	*x = GetCollectionConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionConfigRequest) ProtoMessage() {}
+
+func (x *GetCollectionConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionConfigRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetCollectionConfigRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *GetCollectionConfigRequest) GetCollection() *contract.Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+// GetCollectionConfigResponse contains the configuration of a private data
+// collection
+type GetCollectionConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Config *CollectionConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *GetCollectionConfigResponse) Reset() {
+	*x = GetCollectionConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCollectionConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionConfigResponse) ProtoMessage() {}
+
+func (x *GetCollectionConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionConfigResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetCollectionConfigResponse) GetConfig() *CollectionConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// IsCollectionMemberRequest is sent by the guest to the LedgerService
+// IsCollectionMember host call, to check whether an organisation is a member
+// of a private data collection
+type IsCollectionMemberRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context    *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	Collection *contract.Collection         `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	// The MSP ID to check, or empty for the organisation of the transaction
+	// creator
+	MspId string `protobuf:"bytes,3,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+}
+
+func (x *IsCollectionMemberRequest) Reset() {
+	*x = IsCollectionMemberRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsCollectionMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsCollectionMemberRequest) ProtoMessage() {}
+
+func (x *IsCollectionMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsCollectionMemberRequest.ProtoReflect.Descriptor instead.
+func (*IsCollectionMemberRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *IsCollectionMemberRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *IsCollectionMemberRequest) GetCollection() *contract.Collection {
+	if x != nil {
+		return x.Collection
+	}
+	return nil
+}
+
+func (x *IsCollectionMemberRequest) GetMspId() string {
+	if x != nil {
+		return x.MspId
+	}
+	return ""
+}
+
+// IsCollectionMemberResponse reports whether an organisation is a member of a
+// private data collection
+type IsCollectionMemberResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Member bool `protobuf:"varint,1,opt,name=member,proto3" json:"member,omitempty"`
+	// The MSP ID which was checked
+	MspId string `protobuf:"bytes,2,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+}
+
+func (x *IsCollectionMemberResponse) Reset() {
+	*x = IsCollectionMemberResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsCollectionMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsCollectionMemberResponse) ProtoMessage() {}
+
+func (x *IsCollectionMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsCollectionMemberResponse.ProtoReflect.Descriptor instead.
+func (*IsCollectionMemberResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *IsCollectionMemberResponse) GetMember() bool {
+	if x != nil {
+		return x.Member
+	}
+	return false
+}
+
+func (x *IsCollectionMemberResponse) GetMspId() string {
+	if x != nil {
+		return x.MspId
+	}
+	return ""
+}
+
+// OpenStatesIteratorResponse is returned by the LedgerService
+// OpenStatesIterator host call, which takes a contract.GetStatesRequest, and
+// contains the handle used to read the query results
+type OpenStatesIteratorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// An opaque handle for the iterator, which is only valid for the
+	// transaction which opened it
+	IteratorId string `protobuf:"bytes,1,opt,name=iterator_id,json=iteratorId,proto3" json:"iterator_id,omitempty"`
+}
+
+func (x *OpenStatesIteratorResponse) Reset() {
+	*x = OpenStatesIteratorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenStatesIteratorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenStatesIteratorResponse) ProtoMessage() {}
+
+func (x *OpenStatesIteratorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenStatesIteratorResponse.ProtoReflect.Descriptor instead.
+func (*OpenStatesIteratorResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OpenStatesIteratorResponse) GetIteratorId() string {
+	if x != nil {
+		return x.IteratorId
+	}
+	return ""
+}
+
+// IteratorNextRequest is sent by the guest to the LedgerService IteratorNext
+// host call, to read the next chunk of results from an iterator
+type IteratorNextRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context    *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	IteratorId string                       `protobuf:"bytes,2,opt,name=iterator_id,json=iteratorId,proto3" json:"iterator_id,omitempty"`
+	// The maximum number of states to return, or zero for the host default
+	MaxStates uint32 `protobuf:"varint,3,opt,name=max_states,json=maxStates,proto3" json:"max_states,omitempty"`
+}
+
+func (x *IteratorNextRequest) Reset() {
+	*x = IteratorNextRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorNextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorNextRequest) ProtoMessage() {}
+
+func (x *IteratorNextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorNextRequest.ProtoReflect.Descriptor instead.
+func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *IteratorNextRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *IteratorNextRequest) GetIteratorId() string {
+	if x != nil {
+		return x.IteratorId
+	}
+	return ""
+}
+
+func (x *IteratorNextRequest) GetMaxStates() uint32 {
+	if x != nil {
+		return x.MaxStates
+	}
+	return 0
+}
+
+// IteratorNextResponse contains the next chunk of results from an iterator
+type IteratorNextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*contract.State `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	// True if the iterator has more results
+	HasNext bool `protobuf:"varint,2,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+}
+
+func (x *IteratorNextResponse) Reset() {
+	*x = IteratorNextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorNextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorNextResponse) ProtoMessage() {}
+
+func (x *IteratorNextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorNextResponse.ProtoReflect.Descriptor instead.
+func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IteratorNextResponse) GetStates() []*contract.State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *IteratorNextResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+// IteratorHasNextRequest is sent by the guest to the LedgerService
+// IteratorHasNext host call, to check whether an iterator has more results
+type IteratorHasNextRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context    *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	IteratorId string                       `protobuf:"bytes,2,opt,name=iterator_id,json=iteratorId,proto3" json:"iterator_id,omitempty"`
+}
+
+func (x *IteratorHasNextRequest) Reset() {
+	*x = IteratorHasNextRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorHasNextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorHasNextRequest) ProtoMessage() {}
+
+func (x *IteratorHasNextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorHasNextRequest.ProtoReflect.Descriptor instead.
+func (*IteratorHasNextRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IteratorHasNextRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *IteratorHasNextRequest) GetIteratorId() string {
+	if x != nil {
+		return x.IteratorId
+	}
+	return ""
+}
+
+// IteratorHasNextResponse reports whether an iterator has more results
+type IteratorHasNextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HasNext bool `protobuf:"varint,1,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+}
+
+func (x *IteratorHasNextResponse) Reset() {
+	*x = IteratorHasNextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IteratorHasNextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IteratorHasNextResponse) ProtoMessage() {}
+
+func (x *IteratorHasNextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IteratorHasNextResponse.ProtoReflect.Descriptor instead.
+func (*IteratorHasNextResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *IteratorHasNextResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+// CloseIteratorRequest is sent by the guest to the LedgerService CloseIterator
+// host call, to release an iterator
+type CloseIteratorRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Context    *contract.TransactionContext `protobuf:"bytes,1,opt,name=context,proto3" json:"context,omitempty"`
+	IteratorId string                       `protobuf:"bytes,2,opt,name=iterator_id,json=iteratorId,proto3" json:"iterator_id,omitempty"`
+}
+
+func (x *CloseIteratorRequest) Reset() {
+	*x = CloseIteratorRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseIteratorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseIteratorRequest) ProtoMessage() {}
+
+func (x *CloseIteratorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseIteratorRequest.ProtoReflect.Descriptor instead.
+func (*CloseIteratorRequest) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CloseIteratorRequest) GetContext() *contract.TransactionContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *CloseIteratorRequest) GetIteratorId() string {
+	if x != nil {
+		return x.IteratorId
+	}
+	return ""
+}
+
+// CloseIteratorResponse is returned when an iterator has been closed
+type CloseIteratorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CloseIteratorResponse) Reset() {
+	*x = CloseIteratorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_host_messages_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseIteratorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseIteratorResponse) ProtoMessage() {}
+
+func (x *CloseIteratorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_host_messages_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseIteratorResponse.ProtoReflect.Descriptor instead.
+func (*CloseIteratorResponse) Descriptor() ([]byte, []int) {
+	return file_host_messages_proto_rawDescGZIP(), []int{33}
+}
+
+var File_host_messages_proto protoreflect.FileDescriptor
+
+var file_host_messages_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x1a, 0x15,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x57, 0x0a, 0x10,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x62, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x62, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x78, 0x0a, 0x11, 0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61,
+	0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x62,
+	0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x61, 0x62, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x87, 0x01, 0x0a, 0x0a, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e,
+	0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4c, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x71, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x47, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x57, 0x72, 0x69, 0x74, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x5c, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x70, 0x4f, 0x6e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x99, 0x03, 0x0a, 0x09, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x12,
+	0x36, 0x0a, 0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x36, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x65, 0x78, 0x69,
+	0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x12, 0x41, 0x0a, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x70,
+	0x75, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xf1, 0x03,
+	0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x37, 0x0a,
+	0x06, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12, 0x37, 0x0a, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x06, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x65, 0x78,
+	0x69, 0x73, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x0d, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x68, 0x6f,
+	0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12,
+	0x2d, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x68,
+	0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x03, 0x70, 0x75, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x31, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61,
+	0x70, 0x69, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0x3f, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70,
+	0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x22, 0x7f, 0x0a, 0x09, 0x48, 0x6f, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x26, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
+	0x68, 0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x5a, 0x0a, 0x19, 0x52, 0x65, 0x61, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x25, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22,
+	0xa6, 0x01, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x12, 0x0a, 0x10, 0x50, 0x75, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x9e, 0x01, 0x0a,
+	0x11, 0x50, 0x75, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x14, 0x0a,
+	0x12, 0x50, 0x75, 0x72, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x5f, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x78, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x22, 0x44, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x49, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x4d, 0x53, 0x50, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x5a, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4d, 0x53, 0x50, 0x49,
+	0x44, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x70, 0x49, 0x64,
+	0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x69,
+	0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xbc, 0x03, 0x0a, 0x10, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x72, 0x67,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x4f,
+	0x72, 0x67, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78,
+	0x50, 0x65, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x6f, 0x4c, 0x69, 0x76, 0x65, 0x12, 0x28, 0x0a,
+	0x10, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x72, 0x65, 0x61,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x4f,
+	0x6e, 0x6c, 0x79, 0x52, 0x65, 0x61, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x65, 0x6d, 0x62, 0x65,
+	0x72, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x12, 0x40, 0x0a, 0x1c, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1a, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x49, 0x0a, 0x21, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x1e, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x22, 0x8a, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x50, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x68,
+	0x6f, 0x73, 0x74, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
+	0xa0, 0x01, 0x0a, 0x19, 0x49, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6d,
+	0x73, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x70,
+	0x49, 0x64, 0x22, 0x4b, 0x0a, 0x1a, 0x49, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6d, 0x65, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x73, 0x70, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x73, 0x70, 0x49, 0x64, 0x22,
+	0x3d, 0x0a, 0x1a, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x8d,
+	0x01, 0x0a, 0x13, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x5a,
+	0x0a, 0x14, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x22, 0x71, 0x0a, 0x16, 0x49, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x34, 0x0a,
+	0x17, 0x49, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x61, 0x73, 0x4e, 0x65, 0x78, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f,
+	0x6e, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4e,
+	0x65, 0x78, 0x74, 0x22, 0x6f, 0x0a, 0x14, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x49, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x49, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x34, 0x0a,
+	0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46,
+	0x4f, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x57, 0x41, 0x52, 0x4e, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x03, 0x2a, 0x9f, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x13,
+	0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53,
+	0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x55, 0x50, 0x50, 0x4f,
+	0x52, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49,
+	0x44, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x58, 0x54, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x4e,
+	0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c,
+	0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x05, 0x12, 0x15,
+	0x0a, 0x11, 0x50, 0x45, 0x52, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4e,
+	0x49, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x10, 0x07, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x6e, 0x64,
+	0x61, 0x72, 0x79, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2d, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x63, 0x6f, 0x64, 0x65, 0x2d, 0x77, 0x61, 0x73, 0x6d, 0x2f, 0x68, 0x6f, 0x73, 0x74, 0x61, 0x70,
+	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_host_messages_proto_rawDescOnce sync.Once
+	file_host_messages_proto_rawDescData = file_host_messages_proto_rawDesc
+)
+
+func file_host_messages_proto_rawDescGZIP() []byte {
+	file_host_messages_proto_rawDescOnce.Do(func() {
+		file_host_messages_proto_rawDescData = protoimpl.X.CompressGZIP(file_host_messages_proto_rawDescData)
+	})
+	return file_host_messages_proto_rawDescData
+}
+
+var file_host_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_host_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_host_messages_proto_goTypes = []interface{}{
+	(LogLevel)(0),                        // 0: hostapi.LogLevel
+	(ErrorCode)(0),                       // 1: hostapi.ErrorCode
+	(*HandshakeRequest)(nil),             // 2: hostapi.HandshakeRequest
+	(*HandshakeResponse)(nil),            // 3: hostapi.HandshakeResponse
+	(*LogRequest)(nil),                   // 4: hostapi.LogRequest
+	(*GetWriteSetRequest)(nil),           // 5: hostapi.GetWriteSetRequest
+	(*WriteSetEntry)(nil),                // 6: hostapi.WriteSetEntry
+	(*GetWriteSetResponse)(nil),          // 7: hostapi.GetWriteSetResponse
+	(*BatchRequest)(nil),                 // 8: hostapi.BatchRequest
+	(*BatchItem)(nil),                    // 9: hostapi.BatchItem
+	(*BatchResult)(nil),                  // 10: hostapi.BatchResult
+	(*BatchResponse)(nil),                // 11: hostapi.BatchResponse
+	(*GetMultipleStatesRequest)(nil),     // 12: hostapi.GetMultipleStatesRequest
+	(*GetMultipleStatesResponse)(nil),    // 13: hostapi.GetMultipleStatesResponse
+	(*HostError)(nil),                    // 14: hostapi.HostError
+	(*ReadOptionalStateResponse)(nil),    // 15: hostapi.ReadOptionalStateResponse
+	(*PutStateRequest)(nil),              // 16: hostapi.PutStateRequest
+	(*PutStateResponse)(nil),             // 17: hostapi.PutStateResponse
+	(*PurgeStateRequest)(nil),            // 18: hostapi.PurgeStateRequest
+	(*PurgeStateResponse)(nil),           // 19: hostapi.PurgeStateResponse
+	(*GetTransientRequest)(nil),          // 20: hostapi.GetTransientRequest
+	(*GetTransientResponse)(nil),         // 21: hostapi.GetTransientResponse
+	(*GetMSPIDRequest)(nil),              // 22: hostapi.GetMSPIDRequest
+	(*GetMSPIDResponse)(nil),             // 23: hostapi.GetMSPIDResponse
+	(*CollectionConfig)(nil),             // 24: hostapi.CollectionConfig
+	(*GetCollectionConfigRequest)(nil),   // 25: hostapi.GetCollectionConfigRequest
+	(*GetCollectionConfigResponse)(nil),  // 26: hostapi.GetCollectionConfigResponse
+	(*IsCollectionMemberRequest)(nil),    // 27: hostapi.IsCollectionMemberRequest
+	(*IsCollectionMemberResponse)(nil),   // 28: hostapi.IsCollectionMemberResponse
+	(*OpenStatesIteratorResponse)(nil),   // 29: hostapi.OpenStatesIteratorResponse
+	(*IteratorNextRequest)(nil),          // 30: hostapi.IteratorNextRequest
+	(*IteratorNextResponse)(nil),         // 31: hostapi.IteratorNextResponse
+	(*IteratorHasNextRequest)(nil),       // 32: hostapi.IteratorHasNextRequest
+	(*IteratorHasNextResponse)(nil),      // 33: hostapi.IteratorHasNextResponse
+	(*CloseIteratorRequest)(nil),         // 34: hostapi.CloseIteratorRequest
+	(*CloseIteratorResponse)(nil),        // 35: hostapi.CloseIteratorResponse
+	(*contract.TransactionContext)(nil),  // 36: contract.TransactionContext
+	(*contract.CreateStateRequest)(nil),  // 37: contract.CreateStateRequest
+	(*contract.ReadStateRequest)(nil),    // 38: contract.ReadStateRequest
+	(*contract.UpdateStateRequest)(nil),  // 39: contract.UpdateStateRequest
+	(*contract.DeleteStateRequest)(nil),  // 40: contract.DeleteStateRequest
+	(*contract.ExistsStateRequest)(nil),  // 41: contract.ExistsStateRequest
+	(*contract.CreateStateResponse)(nil), // 42: contract.CreateStateResponse
+	(*contract.ReadStateResponse)(nil),   // 43: contract.ReadStateResponse
+	(*contract.UpdateStateResponse)(nil), // 44: contract.UpdateStateResponse
+	(*contract.DeleteStateResponse)(nil), // 45: contract.DeleteStateResponse
+	(*contract.ExistsStateResponse)(nil), // 46: contract.ExistsStateResponse
+	(*contract.Collection)(nil),          // 47: contract.Collection
+	(*contract.State)(nil),               // 48: contract.State
+}
+var file_host_messages_proto_depIdxs = []int32{
+	36, // 0: hostapi.LogRequest.context:type_name -> contract.TransactionContext
+	0,  // 1: hostapi.LogRequest.level:type_name -> hostapi.LogLevel
+	36, // 2: hostapi.GetWriteSetRequest.context:type_name -> contract.TransactionContext
+	6,  // 3: hostapi.GetWriteSetResponse.entries:type_name -> hostapi.WriteSetEntry
+	9,  // 4: hostapi.BatchRequest.items:type_name -> hostapi.BatchItem
+	37, // 5: hostapi.BatchItem.create:type_name -> contract.CreateStateRequest
+	38, // 6: hostapi.BatchItem.read:type_name -> contract.ReadStateRequest
+	39, // 7: hostapi.BatchItem.update:type_name -> contract.UpdateStateRequest
+	40, // 8: hostapi.BatchItem.delete:type_name -> contract.DeleteStateRequest
+	41, // 9: hostapi.BatchItem.exists:type_name -> contract.ExistsStateRequest
+	38, // 10: hostapi.BatchItem.read_optional:type_name -> contract.ReadStateRequest
+	16, // 11: hostapi.BatchItem.put:type_name -> hostapi.PutStateRequest
+	42, // 12: hostapi.BatchResult.create:type_name -> contract.CreateStateResponse
+	43, // 13: hostapi.BatchResult.read:type_name -> contract.ReadStateResponse
+	44, // 14: hostapi.BatchResult.update:type_name -> contract.UpdateStateResponse
+	45, // 15: hostapi.BatchResult.delete:type_name -> contract.DeleteStateResponse
+	46, // 16: hostapi.BatchResult.exists:type_name -> contract.ExistsStateResponse
+	15, // 17: hostapi.BatchResult.read_optional:type_name -> hostapi.ReadOptionalStateResponse
+	17, // 18: hostapi.BatchResult.put:type_name -> hostapi.PutStateResponse
+	1,  // 19: hostapi.BatchResult.error_code:type_name -> hostapi.ErrorCode
+	10, // 20: hostapi.BatchResponse.results:type_name -> hostapi.BatchResult
+	36, // 21: hostapi.GetMultipleStatesRequest.context:type_name -> contract.TransactionContext
+	47, // 22: hostapi.GetMultipleStatesRequest.collection:type_name -> contract.Collection
+	48, // 23: hostapi.GetMultipleStatesResponse.states:type_name -> contract.State
+	1,  // 24: hostapi.HostError.code:type_name -> hostapi.ErrorCode
+	48, // 25: hostapi.ReadOptionalStateResponse.state:type_name -> contract.State
+	36, // 26: hostapi.PutStateRequest.context:type_name -> contract.TransactionContext
+	48, // 27: hostapi.PutStateRequest.state:type_name -> contract.State
+	47, // 28: hostapi.PutStateRequest.collection:type_name -> contract.Collection
+	36, // 29: hostapi.PurgeStateRequest.context:type_name -> contract.TransactionContext
+	47, // 30: hostapi.PurgeStateRequest.collection:type_name -> contract.Collection
+	36, // 31: hostapi.GetTransientRequest.context:type_name -> contract.TransactionContext
+	36, // 32: hostapi.GetMSPIDRequest.context:type_name -> contract.TransactionContext
+	36, // 33: hostapi.GetCollectionConfigRequest.context:type_name -> contract.TransactionContext
+	47, // 34: hostapi.GetCollectionConfigRequest.collection:type_name -> contract.Collection
+	24, // 35: hostapi.GetCollectionConfigResponse.config:type_name -> hostapi.CollectionConfig
+	36, // 36: hostapi.IsCollectionMemberRequest.context:type_name -> contract.TransactionContext
+	47, // 37: hostapi.IsCollectionMemberRequest.collection:type_name -> contract.Collection
+	36, // 38: hostapi.IteratorNextRequest.context:type_name -> contract.TransactionContext
+	48, // 39: hostapi.IteratorNextResponse.states:type_name -> contract.State
+	36, // 40: hostapi.IteratorHasNextRequest.context:type_name -> contract.TransactionContext
+	36, // 41: hostapi.CloseIteratorRequest.context:type_name -> contract.TransactionContext
+	42, // [42:42] is the sub-list for method output_type
+	42, // [42:42] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_host_messages_proto_init() }
+func file_host_messages_proto_init() {
+	if File_host_messages_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_host_messages_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandshakeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HandshakeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWriteSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WriteSetEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetWriteSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMultipleStatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMultipleStatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadOptionalStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PutStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PurgeStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransientRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransientResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMSPIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMSPIDResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCollectionConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsCollectionMemberRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsCollectionMemberResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenStatesIteratorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorNextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorNextResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorHasNextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IteratorHasNextResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseIteratorRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_host_messages_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseIteratorResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_host_messages_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*BatchItem_Create)(nil),
+		(*BatchItem_Read)(nil),
+		(*BatchItem_Update)(nil),
+		(*BatchItem_Delete)(nil),
+		(*BatchItem_Exists)(nil),
+		(*BatchItem_ReadOptional)(nil),
+		(*BatchItem_Put)(nil),
+	}
+	file_host_messages_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*BatchResult_Create)(nil),
+		(*BatchResult_Read)(nil),
+		(*BatchResult_Update)(nil),
+		(*BatchResult_Delete)(nil),
+		(*BatchResult_Exists)(nil),
+		(*BatchResult_ReadOptional)(nil),
+		(*BatchResult_Put)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_host_messages_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_host_messages_proto_goTypes,
+		DependencyIndexes: file_host_messages_proto_depIdxs,
+		EnumInfos:         file_host_messages_proto_enumTypes,
+		MessageInfos:      file_host_messages_proto_msgTypes,
+	}.Build()
+	File_host_messages_proto = out.File
+	file_host_messages_proto_rawDesc = nil
+	file_host_messages_proto_goTypes = nil
+	file_host_messages_proto_depIdxs = nil
+}
@@ -0,0 +1,107 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// batchRequest executes each ledger operation in a batch in order, using the
+// same handlers as the individual LedgerService operations, so that a guest
+// can access many states with a single host call
+func (proxy *FabricProxy) batchRequest(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.BatchRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	items := request.GetItems()
+	logger.Debug("BatchRequest", zap.Int("items", len(items)), zap.Bool("stopOnError", request.GetStopOnError()))
+
+	response := &hostapi.BatchResponse{
+		Results: make([]*hostapi.BatchResult, 0, len(items)),
+	}
+
+	for _, item := range items {
+		result := proxy.executeBatchItem(ctx, item)
+		response.Results = append(response.Results, result)
+
+		if result.GetError() != "" && request.GetStopOnError() {
+			break
+		}
+	}
+
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) executeBatchItem(ctx context.Context, item *hostapi.BatchItem) (result *hostapi.BatchResult) {
+	operation := batchOperation(item)
+
+	ctx, span := tracer().Start(ctx, "LedgerService."+operation, trace.WithAttributes(OperationAttribute.String(operation)))
+	var err error
+	defer func() {
+		endSpan(span, err)
+		if err != nil {
+			result = &hostapi.BatchResult{Error: err.Error(), ErrorCode: ErrorCode(err)}
+		}
+	}()
+
+	switch r := item.GetRequest().(type) {
+	case *hostapi.BatchItem_Create:
+		err = proxy.handleCreateState(ctx, r.Create)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Create{Create: &contract.CreateStateResponse{}}}
+	case *hostapi.BatchItem_Read:
+		var response *contract.ReadStateResponse
+		response, err = proxy.handleReadState(ctx, r.Read)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Read{Read: response}}
+	case *hostapi.BatchItem_Update:
+		err = proxy.handleUpdateState(ctx, r.Update)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Update{Update: &contract.UpdateStateResponse{}}}
+	case *hostapi.BatchItem_Delete:
+		err = proxy.handleDeleteState(ctx, r.Delete)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Delete{Delete: &contract.DeleteStateResponse{}}}
+	case *hostapi.BatchItem_Exists:
+		var response *contract.ExistsStateResponse
+		response, err = proxy.handleExistsState(ctx, r.Exists)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Exists{Exists: response}}
+	case *hostapi.BatchItem_Put:
+		err = proxy.handlePutState(ctx, r.Put)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_Put{Put: &hostapi.PutStateResponse{}}}
+	case *hostapi.BatchItem_ReadOptional:
+		var response *hostapi.ReadOptionalStateResponse
+		response, err = proxy.handleReadOptionalState(ctx, r.ReadOptional)
+		return &hostapi.BatchResult{Result: &hostapi.BatchResult_ReadOptional{ReadOptional: response}}
+	default:
+		err = newOperationError(hostapi.ErrorCode_INVALID_REQUEST, "BatchRequest", "", "", "Unsupported request type %T", r)
+		return nil
+	}
+}
+
+func batchOperation(item *hostapi.BatchItem) string {
+	switch item.GetRequest().(type) {
+	case *hostapi.BatchItem_Create:
+		return "CreateState"
+	case *hostapi.BatchItem_Read:
+		return "ReadState"
+	case *hostapi.BatchItem_Update:
+		return "UpdateState"
+	case *hostapi.BatchItem_Delete:
+		return "DeleteState"
+	case *hostapi.BatchItem_Exists:
+		return "ExistsState"
+	case *hostapi.BatchItem_ReadOptional:
+		return "ReadOptionalState"
+	case *hostapi.BatchItem_Put:
+		return "PutState"
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,237 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// memberPrincipal matches the MSP ID in a principal in a signature policy,
+// e.g. 'Org1MSP.member'
+var memberPrincipal = regexp.MustCompile(`'([^'.]+)\.(member|peer|admin|client|orderer)'`)
+
+// CollectionsConfig is used to configure where the private data collection
+// configuration is loaded from
+type CollectionsConfig struct {
+	File string `yaml:"file" env:"CHAINCODE_COLLECTIONS_CONFIG" usage:"collections_config.json file used for the chaincode definition"`
+}
+
+// collectionDefinition is a collection in a collections_config.json file, as
+// used with the peer lifecycle chaincode commands
+type collectionDefinition struct {
+	Name              string `json:"name"`
+	Policy            string `json:"policy"`
+	RequiredPeerCount uint32 `json:"requiredPeerCount"`
+	MaxPeerCount      uint32 `json:"maxPeerCount"`
+	BlockToLive       uint64 `json:"blockToLive"`
+	MemberOnlyRead    bool   `json:"memberOnlyRead"`
+	MemberOnlyWrite   bool   `json:"memberOnlyWrite"`
+	EndorsementPolicy *struct {
+		SignaturePolicy     string `json:"signaturePolicy"`
+		ChannelConfigPolicy string `json:"channelConfigPolicy"`
+	} `json:"endorsementPolicy"`
+}
+
+// CollectionConfigs are the private data collections in the chaincode
+// definition
+type CollectionConfigs struct {
+	collections map[string]*hostapi.CollectionConfig
+}
+
+// LoadCollectionConfigs loads the private data collection configuration from a
+// collections_config.json file, and returns nil if no file is configured
+func LoadCollectionConfigs(config CollectionsConfig) (*CollectionConfigs, error) {
+	if config.File == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(config.File)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read collections configuration: %s", err.Error())
+	}
+
+	var definitions []collectionDefinition
+	err = json.Unmarshal(data, &definitions)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid collections configuration %s: %s", config.File, err.Error())
+	}
+
+	configs := &CollectionConfigs{
+		collections: make(map[string]*hostapi.CollectionConfig),
+	}
+
+	for _, definition := range definitions {
+		if definition.Name == "" {
+			return nil, fmt.Errorf("Invalid collections configuration %s: Collection name must be specified", config.File)
+		}
+
+		if _, ok := configs.collections[definition.Name]; ok {
+			return nil, fmt.Errorf("Invalid collections configuration %s: Duplicate collection %s", config.File, definition.Name)
+		}
+
+		collectionConfig := &hostapi.CollectionConfig{
+			Name:              definition.Name,
+			Policy:            definition.Policy,
+			MemberOrgs:        memberOrgs(definition.Policy),
+			RequiredPeerCount: definition.RequiredPeerCount,
+			MaxPeerCount:      definition.MaxPeerCount,
+			BlockToLive:       definition.BlockToLive,
+			MemberOnlyRead:    definition.MemberOnlyRead,
+			MemberOnlyWrite:   definition.MemberOnlyWrite,
+		}
+
+		if definition.EndorsementPolicy != nil {
+			collectionConfig.EndorsementSignaturePolicy = definition.EndorsementPolicy.SignaturePolicy
+			collectionConfig.EndorsementChannelConfigPolicy = definition.EndorsementPolicy.ChannelConfigPolicy
+		}
+
+		configs.collections[definition.Name] = collectionConfig
+	}
+
+	return configs, nil
+}
+
+// Get returns the configuration for a collection
+func (configs *CollectionConfigs) Get(name string) (*hostapi.CollectionConfig, bool) {
+	if configs == nil {
+		return nil, false
+	}
+
+	config, ok := configs.collections[name]
+	return config, ok
+}
+
+// memberOrgs returns the MSP IDs in a signature policy, in the order they
+// first appear
+func memberOrgs(policy string) []string {
+	var orgs []string
+	seen := make(map[string]bool)
+
+	for _, match := range memberPrincipal.FindAllStringSubmatch(policy, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			orgs = append(orgs, match[1])
+		}
+	}
+
+	return orgs
+}
+
+// SetCollectionConfigs sets the private data collection configuration
+// available to the guest
+func (proxy *FabricProxy) SetCollectionConfigs(configs *CollectionConfigs) {
+	proxy.collections = configs
+}
+
+// collectionConfig returns the configuration for a collection in a request,
+// which may be a symbolic implicit collection name
+func (proxy *FabricProxy) collectionConfig(operation string, collection string) (*hostapi.CollectionConfig, error) {
+	if proxy.collections == nil {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, operation, collection, "", "No collections configuration has been loaded")
+	}
+
+	collectionName, err := resolveCollection(collection, proxy.mspID)
+	if err != nil {
+		return nil, wrapOperationError(operation, collection, "", err)
+	}
+
+	config, ok := proxy.collections.Get(collectionName)
+	if !ok {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, operation, collection, "", "No configuration exists for collection %s", collectionName)
+	}
+
+	return config, nil
+}
+
+func (proxy *FabricProxy) getCollectionConfig(payload []byte) ([]byte, error) {
+	request := &hostapi.GetCollectionConfigRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	collection := request.GetCollection().GetName()
+	logger.Debug("GetCollectionConfig", append(contextFields(request.GetContext()), zap.String("collection", collection))...)
+
+	config, err := proxy.collectionConfig("GetCollectionConfig", collection)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &hostapi.GetCollectionConfigResponse{
+		Config: config,
+	}
+
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) isCollectionMember(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.IsCollectionMemberRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	collection := request.GetCollection().GetName()
+	logger.Debug("IsCollectionMember", append(contextFields(context), zap.String("collection", collection), zap.String("mspID", request.GetMspId()))...)
+	traceRequest(ctx, context, "", request.GetCollection())
+
+	config, err := proxy.collectionConfig("IsCollectionMember", collection)
+	if err != nil {
+		return nil, err
+	}
+
+	mspID := request.GetMspId()
+	if mspID == "" {
+		mspID, err = proxy.creatorMSPID(context)
+		if err != nil {
+			return nil, wrapOperationError("IsCollectionMember", collection, "", err)
+		}
+	}
+
+	response := &hostapi.IsCollectionMemberResponse{
+		MspId: mspID,
+	}
+
+	for _, org := range config.GetMemberOrgs() {
+		if org == mspID {
+			response.Member = true
+		}
+	}
+
+	return proto.Marshal(response)
+}
+
+// creatorMSPID returns the MSP ID of the transaction creator
+func (proxy *FabricProxy) creatorMSPID(context *contract.TransactionContext) (string, error) {
+	stub, err := proxy.transactionStub(context)
+	if err != nil {
+		return "", err
+	}
+
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", err
+	}
+
+	identity := &msp.SerializedIdentity{}
+	err = protov1.Unmarshal(creator, identity)
+	if err != nil {
+		return "", fmt.Errorf("Invalid transaction creator: %s", err.Error())
+	}
+
+	return identity.GetMspid(), nil
+}
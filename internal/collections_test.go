@@ -0,0 +1,198 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"google.golang.org/protobuf/proto"
+)
+
+const collectionsConfig = `[
+	{
+		"name": "assetCollection",
+		"policy": "OR('Org1MSP.member', 'Org2MSP.member', 'Org1MSP.peer')",
+		"requiredPeerCount": 1,
+		"maxPeerCount": 3,
+		"blockToLive": 1000000,
+		"memberOnlyRead": true,
+		"memberOnlyWrite": true
+	},
+	{
+		"name": "_implicit_org_Org1MSP",
+		"policy": "OR('Org1MSP.member')",
+		"endorsementPolicy": {
+			"signaturePolicy": "OR('Org1MSP.peer')"
+		}
+	}
+]`
+
+var _ = Describe("Collections", func() {
+	var (
+		tempDir      string
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		stub         *fakes.ChaincodeStubInterface
+		txContext    *contract.TransactionContext
+		ctx          context.Context
+	)
+
+	writeCollectionsFile := func(contents string) string {
+		collectionsFile := filepath.Join(tempDir, "collections_config.json")
+		Expect(ioutil.WriteFile(collectionsFile, []byte(contents), 0600)).To(Succeed())
+		return collectionsFile
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "collections")
+		Expect(err).NotTo(HaveOccurred())
+
+		configs, err := internal.LoadCollectionConfigs(internal.CollectionsConfig{File: writeCollectionsFile(collectionsConfig)})
+		Expect(err).NotTo(HaveOccurred())
+
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		proxy.SetMSPID("Org1MSP")
+		proxy.SetCollectionConfigs(configs)
+		ctx = context.Background()
+
+		creator, _ := protov1.Marshal(&msp.SerializedIdentity{Mspid: "Org2MSP"})
+		stub = &fakes.ChaincodeStubInterface{}
+		stub.GetCreatorReturns(creator, nil)
+		contextStore.Put("channel1", "txn1", stub)
+		txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	getCollectionConfig := func(collection string) (*hostapi.CollectionConfig, error) {
+		payload, _ := proto.Marshal(&hostapi.GetCollectionConfigRequest{Context: txContext, Collection: &contract.Collection{Name: collection}})
+		result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetCollectionConfig", payload)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &hostapi.GetCollectionConfigResponse{}
+		Expect(proto.Unmarshal(result, response)).To(Succeed())
+		return response.GetConfig(), nil
+	}
+
+	isCollectionMember := func(collection string, mspID string) (*hostapi.IsCollectionMemberResponse, error) {
+		payload, _ := proto.Marshal(&hostapi.IsCollectionMemberRequest{Context: txContext, Collection: &contract.Collection{Name: collection}, MspId: mspID})
+		result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "IsCollectionMember", payload)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &hostapi.IsCollectionMemberResponse{}
+		Expect(proto.Unmarshal(result, response)).To(Succeed())
+		return response, nil
+	}
+
+	Describe("LoadCollectionConfigs", func() {
+		It("should return nil if no file is configured", func() {
+			configs, err := internal.LoadCollectionConfigs(internal.CollectionsConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(BeNil())
+		})
+
+		It("should fail if the file does not exist", func() {
+			_, err := internal.LoadCollectionConfigs(internal.CollectionsConfig{File: filepath.Join(tempDir, "missing.json")})
+			Expect(err).To(MatchError(ContainSubstring("Unable to read collections configuration")))
+		})
+
+		It("should fail with invalid JSON", func() {
+			collectionsFile := writeCollectionsFile("{")
+			_, err := internal.LoadCollectionConfigs(internal.CollectionsConfig{File: collectionsFile})
+			Expect(err).To(MatchError(ContainSubstring("Invalid collections configuration " + collectionsFile)))
+		})
+
+		It("should fail with duplicate collections", func() {
+			collectionsFile := writeCollectionsFile(`[{"name": "private"}, {"name": "private"}]`)
+			_, err := internal.LoadCollectionConfigs(internal.CollectionsConfig{File: collectionsFile})
+			Expect(err).To(MatchError("Invalid collections configuration " + collectionsFile + ": Duplicate collection private"))
+		})
+	})
+
+	Describe("GetCollectionConfig", func() {
+		It("should return the collection configuration", func() {
+			config, err := getCollectionConfig("assetCollection")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.GetName()).To(Equal("assetCollection"))
+			Expect(config.GetMemberOrgs()).To(Equal([]string{"Org1MSP", "Org2MSP"}))
+			Expect(config.GetRequiredPeerCount()).To(Equal(uint32(1)))
+			Expect(config.GetMaxPeerCount()).To(Equal(uint32(3)))
+			Expect(config.GetBlockToLive()).To(Equal(uint64(1000000)))
+			Expect(config.GetMemberOnlyRead()).To(BeTrue())
+			Expect(config.GetMemberOnlyWrite()).To(BeTrue())
+		})
+
+		It("should resolve symbolic implicit collection names", func() {
+			config, err := getCollectionConfig("$implicit_org")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.GetName()).To(Equal("_implicit_org_Org1MSP"))
+			Expect(config.GetEndorsementSignaturePolicy()).To(Equal("OR('Org1MSP.peer')"))
+		})
+
+		It("should fail for an unknown collection", func() {
+			_, err := getCollectionConfig("private")
+			Expect(err).To(MatchError("GetCollectionConfig failed for collection private: No configuration exists for collection private"))
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+		})
+
+		It("should fail if no collections configuration has been loaded", func() {
+			proxy.SetCollectionConfigs(nil)
+
+			_, err := getCollectionConfig("assetCollection")
+			Expect(err).To(MatchError("GetCollectionConfig failed for collection assetCollection: No collections configuration has been loaded"))
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_SUPPORTED))
+		})
+	})
+
+	Describe("IsCollectionMember", func() {
+		It("should check the transaction creator's organisation by default", func() {
+			response, err := isCollectionMember("assetCollection", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetMember()).To(BeTrue())
+			Expect(response.GetMspId()).To(Equal("Org2MSP"))
+		})
+
+		It("should report when the transaction creator's organisation is not a member", func() {
+			response, err := isCollectionMember("$implicit_org", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetMember()).To(BeFalse())
+			Expect(response.GetMspId()).To(Equal("Org2MSP"))
+		})
+
+		It("should check a specified organisation", func() {
+			response, err := isCollectionMember("assetCollection", "Org3MSP")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetMember()).To(BeFalse())
+			Expect(response.GetMspId()).To(Equal("Org3MSP"))
+			Expect(stub.GetCreatorCallCount()).To(Equal(0))
+		})
+
+		It("should fail with an invalid transaction creator", func() {
+			stub.GetCreatorReturns([]byte("\xff"), nil)
+
+			_, err := isCollectionMember("assetCollection", "")
+			Expect(err).To(MatchError(ContainSubstring("IsCollectionMember failed for collection assetCollection: Invalid transaction creator")))
+		})
+	})
+})
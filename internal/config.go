@@ -0,0 +1,350 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFileEnv is the environment variable used to specify a configuration
+// file, if the --config flag is not used
+const ConfigFileEnv = "CHAINCODE_CONFIG_FILE"
+
+const redacted = "<redacted>"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// WasmConfig is used to configure where the Wasm module is loaded from
+type WasmConfig struct {
+	// File is the path of a raw .wasm file
+	File string `yaml:"file" env:"CHAINCODE_WASM_FILE" usage:"Wasm module file"`
+	// Package is the path of a chaincode package or OCI bundle, and takes
+	// precedence over File
+	Package string `yaml:"package" env:"CHAINCODE_WASM_PACKAGE" usage:"chaincode package or OCI bundle containing the Wasm module"`
+}
+
+// Path returns the path to load the Wasm module from
+func (config WasmConfig) Path() string {
+	if config.Package != "" {
+		return config.Package
+	}
+
+	return config.File
+}
+
+// Config contains every runtime setting for the Wasm chaincode. Settings are
+// loaded from defaults, an optional YAML or JSON configuration file,
+// environment variables, and command line flags, in increasing order of
+// precedence. Flag names are the dotted YAML names, e.g. --tls.disabled
+type Config struct {
+	CCID            string            `yaml:"ccid" env:"CHAINCODE_ID" usage:"chaincode package ID"`
+	MSPID           string            `yaml:"mspID" env:"CORE_PEER_LOCALMSPID" usage:"MSP ID of the peer organisation, for implicit private data collections"`
+	Address         string            `yaml:"address" env:"CHAINCODE_SERVER_ADDRESS" usage:"host and port for the chaincode server, or empty to connect to the peer"`
+	ShutdownTimeout time.Duration     `yaml:"shutdownTimeout" env:"CHAINCODE_SHUTDOWN_TIMEOUT" usage:"maximum time to wait for in-flight transactions when shutting down"`
+	Wasm            WasmConfig        `yaml:"wasm"`
+	Pool            PoolConfig        `yaml:"pool"`
+	TLS             TLSConfig         `yaml:"tls"`
+	Logging         LogConfig         `yaml:"logging"`
+	GuestLogging    GuestLogConfig    `yaml:"guestLogging"`
+	Metrics         MetricsConfig     `yaml:"metrics"`
+	Health          HealthConfig      `yaml:"health"`
+	Tracing         TracingConfig     `yaml:"tracing"`
+	Hash            HashConfig        `yaml:"hash"`
+	Collections     CollectionsConfig `yaml:"collections"`
+}
+
+// DefaultConfig returns the configuration used when settings are not specified
+func DefaultConfig() *Config {
+	return &Config{
+		ShutdownTimeout: 25 * time.Second,
+		Pool: PoolConfig{
+			Size:    10,
+			Timeout: 10 * time.Millisecond,
+		},
+		TLS: TLSConfig{
+			Disabled: true,
+		},
+		Logging: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		GuestLogging: GuestLogConfig{
+			Level: "info",
+		},
+		Health: HealthConfig{
+			WedgedTimeout: DefaultWedgedTimeout,
+		},
+		Hash: HashConfig{
+			Algorithm: DefaultHashAlgorithm,
+		},
+	}
+}
+
+// configField is a single setting in the configuration
+type configField struct {
+	name   string
+	env    string
+	usage  string
+	redact bool
+	value  reflect.Value
+}
+
+// configFields returns every setting in the configuration struct, named using
+// the dotted YAML names of the struct fields
+func configFields(value reflect.Value, prefix string) []configField {
+	var fields []configField
+
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+
+		yamlName := strings.Split(structField.Tag.Get("yaml"), ",")[0]
+		if yamlName == "-" {
+			continue
+		}
+		name := prefix + yamlName
+
+		if structField.Type.Kind() == reflect.Struct && structField.Type != durationType {
+			fields = append(fields, configFields(value.Field(i), name+".")...)
+			continue
+		}
+
+		fields = append(fields, configField{
+			name:   name,
+			env:    structField.Tag.Get("env"),
+			usage:  structField.Tag.Get("usage"),
+			redact: structField.Tag.Get("redact") == "true",
+			value:  value.Field(i),
+		})
+	}
+
+	return fields
+}
+
+func (field configField) set(text string) error {
+	var err error
+
+	switch {
+	case field.value.Type() == durationType:
+		var duration time.Duration
+		duration, err = time.ParseDuration(text)
+		field.value.SetInt(int64(duration))
+	case field.value.Kind() == reflect.String:
+		field.value.SetString(text)
+	case field.value.Kind() == reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(text)
+		field.value.SetBool(b)
+	case field.value.Kind() == reflect.Int:
+		var i int64
+		i, err = strconv.ParseInt(text, 10, 0)
+		field.value.SetInt(i)
+	case field.value.Kind() == reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(text, 64)
+		field.value.SetFloat(f)
+	default:
+		err = fmt.Errorf("unsupported type %s", field.value.Type())
+	}
+
+	if err != nil {
+		return fmt.Errorf("Invalid value %q for %s: %s", text, field.name, err.Error())
+	}
+
+	return nil
+}
+
+// define registers a command line flag for the setting, with the type of the
+// setting, so that invalid values are rejected when parsing the flags and
+// boolean flags can be used without a value
+func (field configField) define(flags *flag.FlagSet) {
+	usage := fmt.Sprintf("%s (%s)", field.usage, field.env)
+
+	switch {
+	case field.value.Type() == durationType:
+		flags.Duration(field.name, time.Duration(field.value.Int()), usage)
+	case field.value.Kind() == reflect.Bool:
+		flags.Bool(field.name, field.value.Bool(), usage)
+	case field.value.Kind() == reflect.Int:
+		flags.Int(field.name, int(field.value.Int()), usage)
+	case field.value.Kind() == reflect.Float64:
+		flags.Float64(field.name, field.value.Float(), usage)
+	default:
+		flags.String(field.name, field.String(), usage)
+	}
+}
+
+func (field configField) String() string {
+	if field.value.Type() == durationType {
+		return time.Duration(field.value.Int()).String()
+	}
+
+	return fmt.Sprint(field.value.Interface())
+}
+
+// LoadConfig returns the configuration specified by the command line
+// arguments, environment variables, and optional configuration file
+func LoadConfig(name string, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	config := DefaultConfig()
+	fields := configFields(reflect.ValueOf(config).Elem(), "")
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	configFile := flags.String("config", "", fmt.Sprintf("YAML or JSON configuration file (%s)", ConfigFileEnv))
+	for _, field := range fields {
+		field.define(flags)
+	}
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if *configFile == "" {
+		*configFile, _ = lookupEnv(ConfigFileEnv)
+	}
+
+	if *configFile != "" {
+		data, err := ioutil.ReadFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read configuration file: %s", err.Error())
+		}
+
+		err = yaml.UnmarshalStrict(data, config)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid configuration file %s: %s", *configFile, err.Error())
+		}
+	}
+
+	// Empty environment variables are ignored, as if they were not set
+	for _, field := range fields {
+		if text, ok := lookupEnv(field.env); ok && text != "" {
+			err := field.set(text)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var flagErr error
+	flags.Visit(func(f *flag.Flag) {
+		for _, field := range fields {
+			if field.name == f.Name && flagErr == nil {
+				flagErr = field.set(f.Value.String())
+			}
+		}
+	})
+	if flagErr != nil {
+		return nil, flagErr
+	}
+
+	return config, nil
+}
+
+// Validate checks every setting in the configuration, and returns an error
+// listing all the problems found
+func (config *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	if config.Wasm.Path() == "" {
+		problems = append(problems, "wasm.file or wasm.package must be specified")
+	} else if _, err := os.Stat(config.Wasm.Path()); err != nil {
+		problems = append(problems, fmt.Sprintf("Unable to access Wasm module: %s", err.Error()))
+	}
+
+	if config.Address != "" {
+		check(validAddress(config.Address), "address %s must be a host and port", config.Address)
+		check(config.CCID != "", "ccid must be specified with address")
+	}
+	check(config.ShutdownTimeout > 0, "shutdownTimeout must be greater than zero")
+
+	check(config.Pool.Size > 0, "pool.size must be greater than zero")
+	check(config.Pool.Timeout > 0, "pool.timeout must be greater than zero")
+
+	if !config.TLS.Disabled {
+		check(config.TLS.KeyFile != "" && config.TLS.CertFile != "", "tls.key and tls.cert must be specified when TLS is enabled")
+	}
+
+	check(validLevel(config.Logging.Level), "logging.level %s must be one of debug, info, warn or error", config.Logging.Level)
+	check(validFormat(config.Logging.Format), "logging.format %s must be json or console", config.Logging.Format)
+
+	check(validLevel(config.GuestLogging.Level), "guestLogging.level %s must be one of debug, info, warn or error", config.GuestLogging.Level)
+	check(config.GuestLogging.Rate >= 0, "guestLogging.rate must not be negative")
+	check(config.GuestLogging.Burst >= 0, "guestLogging.burst must not be negative")
+
+	if config.Metrics.Address != "" {
+		check(validAddress(config.Metrics.Address), "metrics.address %s must be a host and port", config.Metrics.Address)
+	}
+
+	if config.Health.Address != "" {
+		check(validAddress(config.Health.Address), "health.address %s must be a host and port", config.Health.Address)
+	}
+	check(config.Health.WedgedTimeout > 0, "health.wedgedTimeout must be greater than zero")
+
+	switch config.Tracing.exporter() {
+	case "":
+	case "otlp":
+		check(config.Tracing.Endpoint != "", "tracing.endpoint must be specified for the otlp exporter")
+	case "file":
+		check(config.Tracing.File != "", "tracing.file must be specified for the file exporter")
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter %s must be otlp, file, or empty", config.Tracing.Exporter))
+	}
+
+	check(validHashAlgorithm(config.Hash.Algorithm), "hash.algorithm %s must be one of %s", config.Hash.Algorithm, hashAlgorithmNames())
+
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid configuration: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Summary returns the effective value of every setting, with secrets redacted
+func (config *Config) Summary() map[string]string {
+	summary := make(map[string]string)
+
+	for _, field := range configFields(reflect.ValueOf(config).Elem(), "") {
+		value := field.String()
+		if field.redact && value != "" {
+			value = redacted
+		}
+		summary[field.name] = value
+	}
+
+	return summary
+}
+
+func validAddress(address string) bool {
+	_, port, err := net.SplitHostPort(address)
+	return err == nil && port != ""
+}
+
+func validLevel(level string) bool {
+	var zapLevel zapcore.Level
+	return zapLevel.UnmarshalText([]byte(strings.ToLower(level))) == nil
+}
+
+func validFormat(format string) bool {
+	switch strings.ToLower(format) {
+	case "", "json", "console":
+		return true
+	}
+
+	return false
+}
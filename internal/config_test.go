@@ -0,0 +1,205 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		tempDir  string
+		wasmFile string
+		env      map[string]string
+	)
+
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	writeConfigFile := func(name string, contents string) string {
+		configFile := filepath.Join(tempDir, name)
+		Expect(ioutil.WriteFile(configFile, []byte(contents), 0600)).To(Succeed())
+		return configFile
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "config")
+		Expect(err).NotTo(HaveOccurred())
+
+		wasmFile = filepath.Join(tempDir, "fabcar.wasm")
+		Expect(ioutil.WriteFile(wasmFile, []byte("\x00asm\x01\x00\x00\x00"), 0600)).To(Succeed())
+
+		env = make(map[string]string)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("LoadConfig", func() {
+		It("should use the default configuration", func() {
+			config, err := internal.LoadConfig("wasmcc", nil, lookupEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(internal.DefaultConfig()))
+		})
+
+		It("should load a YAML configuration file", func() {
+			configFile := writeConfigFile("wasmcc.yaml", `
+ccid: wasm:1234
+address: 0.0.0.0:9999
+wasm:
+  file: /local/fabcar.wasm
+pool:
+  size: 4
+  timeout: 50ms
+tls:
+  disabled: false
+  key: /certs/server.key
+  cert: /certs/server.crt
+guestLogging:
+  rate: 2.5
+`)
+
+			config, err := internal.LoadConfig("wasmcc", []string{"--config", configFile}, lookupEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.CCID).To(Equal("wasm:1234"))
+			Expect(config.Address).To(Equal("0.0.0.0:9999"))
+			Expect(config.Wasm.File).To(Equal("/local/fabcar.wasm"))
+			Expect(config.Pool).To(Equal(internal.PoolConfig{Size: 4, Timeout: 50 * time.Millisecond}))
+			Expect(config.TLS).To(Equal(internal.TLSConfig{KeyFile: "/certs/server.key", CertFile: "/certs/server.crt"}))
+			Expect(config.GuestLogging.Rate).To(Equal(2.5))
+			Expect(config.Logging.Level).To(Equal("info"))
+		})
+
+		It("should load a JSON configuration file specified by an environment variable", func() {
+			env[internal.ConfigFileEnv] = writeConfigFile("wasmcc.json", `{"health": {"address": ":9444", "wedgedTimeout": "1m"}}`)
+
+			config, err := internal.LoadConfig("wasmcc", nil, lookupEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Health).To(Equal(internal.HealthConfig{Address: ":9444", WedgedTimeout: time.Minute}))
+		})
+
+		It("should fail with an unknown setting in the configuration file", func() {
+			configFile := writeConfigFile("wasmcc.yaml", "pool:\n  sise: 4\n")
+
+			_, err := internal.LoadConfig("wasmcc", []string{"--config", configFile}, lookupEnv)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("Invalid configuration file " + configFile))
+		})
+
+		It("should override the configuration file with environment variables and flags", func() {
+			configFile := writeConfigFile("wasmcc.yaml", "logging:\n  level: warn\n  format: console\npool:\n  size: 4\n")
+			env["CHAINCODE_LOG_LEVEL"] = "debug"
+			env["CHAINCODE_POOL_SIZE"] = "6"
+			env["CHAINCODE_METRICS_ADDRESS"] = ""
+
+			config, err := internal.LoadConfig("wasmcc", []string{"--config", configFile, "--pool.size", "8"}, lookupEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Logging).To(Equal(internal.LogConfig{Level: "debug", Format: "console"}))
+			Expect(config.Pool.Size).To(Equal(8))
+			Expect(config.Metrics.Address).To(BeEmpty())
+		})
+
+		It("should fail with an invalid environment variable", func() {
+			env["CHAINCODE_TLS_DISABLED"] = "nope"
+
+			_, err := internal.LoadConfig("wasmcc", nil, lookupEnv)
+			Expect(err).To(MatchError(`Invalid value "nope" for tls.disabled: strconv.ParseBool: parsing "nope": invalid syntax`))
+		})
+
+		It("should fail with an invalid flag", func() {
+			_, err := internal.LoadConfig("wasmcc", []string{"--shutdownTimeout", "soon"}, lookupEnv)
+			Expect(err).To(MatchError(`invalid value "soon" for flag -shutdownTimeout: parse error`))
+		})
+
+		It("should parse typed flags", func() {
+			config, err := internal.LoadConfig("wasmcc", []string{
+				"--tls.disabled=false",
+				"--tracing.insecure",
+				"--pool.size", "3",
+				"--pool.timeout", "250ms",
+				"--guestLogging.rate", "2.5",
+			}, lookupEnv)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.TLS.Disabled).To(BeFalse())
+			Expect(config.Tracing.Insecure).To(BeTrue(), "Should set a boolean flag without a value")
+			Expect(config.Pool.Size).To(Equal(3))
+			Expect(config.Pool.Timeout).To(Equal(250 * time.Millisecond))
+			Expect(config.GuestLogging.Rate).To(Equal(2.5))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("should accept a valid configuration", func() {
+			config := internal.DefaultConfig()
+			config.Wasm.File = wasmFile
+
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("should report every problem with the configuration", func() {
+			config := internal.DefaultConfig()
+			config.Address = "9999"
+			config.Pool.Size = 0
+			config.TLS.Disabled = false
+			config.Logging.Level = "chatty"
+			config.Tracing.Exporter = "otlp"
+			config.Hash.Algorithm = "md5"
+
+			Expect(config.Validate()).To(MatchError("Invalid configuration: " +
+				"wasm.file or wasm.package must be specified; " +
+				"address 9999 must be a host and port; " +
+				"ccid must be specified with address; " +
+				"pool.size must be greater than zero; " +
+				"tls.key and tls.cert must be specified when TLS is enabled; " +
+				"logging.level chatty must be one of debug, info, warn or error; " +
+				"tracing.endpoint must be specified for the otlp exporter; " +
+				"hash.algorithm md5 must be one of sha256, sha384, sha512"))
+		})
+
+		It("should accept a trace exporter in any case", func() {
+			config := internal.DefaultConfig()
+			config.Wasm.File = wasmFile
+			config.Tracing.Exporter = "OTLP"
+			config.Tracing.Endpoint = "localhost:4317"
+
+			Expect(config.Validate()).To(Succeed())
+		})
+
+		It("should fail when the Wasm module does not exist", func() {
+			config := internal.DefaultConfig()
+			config.Wasm.Package = filepath.Join(tempDir, "missing.tgz")
+
+			err := config.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("Invalid configuration: Unable to access Wasm module"))
+		})
+	})
+
+	Describe("Summary", func() {
+		It("should list every setting with secrets redacted", func() {
+			config := internal.DefaultConfig()
+			config.TLS.KeyFile = "/certs/server.key"
+			config.TLS.CertFile = "/certs/server.crt"
+
+			summary := config.Summary()
+			Expect(summary).To(HaveKeyWithValue("tls.key", "<redacted>"))
+			Expect(summary).To(HaveKeyWithValue("tls.cert", "/certs/server.crt"))
+			Expect(summary).To(HaveKeyWithValue("pool.size", "10"))
+			Expect(summary).To(HaveKeyWithValue("shutdownTimeout", "25s"))
+			Expect(summary).NotTo(HaveKey("tracing.serviceName"))
+		})
+	})
+})
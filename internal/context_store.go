@@ -4,28 +4,52 @@
 package internal
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"sync"
 
 	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.uber.org/zap"
 )
 
 type stubKey struct {
 	channelID, txID string
 }
 
+func (key stubKey) fields() []zap.Field {
+	return []zap.Field{
+		zap.String("channel", key.channelID),
+		zap.String("txid", key.txID),
+	}
+}
+
+// transaction is the state kept for each in-flight transaction
+type transaction struct {
+	stub shim.ChaincodeStubInterface
+	// readOnlyFunction is the name of the transaction function if it must not
+	// write to the ledger
+	readOnlyFunction string
+	// writes is the optional read-your-own-writes overlay
+	writes *WriteSet
+	// resources are released when the transaction ends
+	resources *ResourceScope
+}
+
 // ContextStore keeps track of which stub belongs to which channel ID + transaction ID context
 type ContextStore struct {
 	sync.RWMutex
-	stubs map[stubKey]shim.ChaincodeStubInterface
+	stubs       map[stubKey]*transaction
+	draining    bool
+	drained     chan struct{}
+	drainedOnce sync.Once
 }
 
 // NewContextStore returns a new store for keeping track of transaction context stubs
 func NewContextStore() *ContextStore {
 	store := ContextStore{}
-	store.stubs = make(map[stubKey]shim.ChaincodeStubInterface)
+	store.stubs = make(map[stubKey]*transaction)
+	store.drained = make(chan struct{})
 
 	return &store
 }
@@ -37,7 +61,7 @@ func (store *ContextStore) Get(context *contract.TransactionContext) (shim.Chain
 		txID:      context.TransactionId,
 	}
 
-	log.Printf("[host] Getting stub for context chid %s txid %s\n", key.channelID, key.txID)
+	logger.Debug("Getting stub", key.fields()...)
 
 	store.RLock()
 	defer store.RUnlock()
@@ -46,7 +70,7 @@ func (store *ContextStore) Get(context *contract.TransactionContext) (shim.Chain
 		return nil, fmt.Errorf("No stub found for transaction context %s %s", key.channelID, key.txID)
 	}
 
-	stub := store.stubs[key]
+	stub := store.stubs[key].stub
 
 	return stub, nil
 }
@@ -58,16 +82,21 @@ func (store *ContextStore) Put(channelID string, txID string, stub shim.Chaincod
 		txID,
 	}
 
-	log.Printf("[host] Putting stub for context chid %s txid %s\n", key.channelID, key.txID)
+	logger.Debug("Putting stub", key.fields()...)
 
 	store.Lock()
 	defer store.Unlock()
 
+	if store.draining {
+		return fmt.Errorf("Wasm chaincode is shutting down, rejecting transaction context %s %s", key.channelID, key.txID)
+	}
+
 	if _, ok := store.stubs[key]; ok {
 		return fmt.Errorf("Stub already exists for transaction context %s %s", key.channelID, key.txID)
 	}
 
-	store.stubs[key] = stub
+	store.stubs[key] = &transaction{stub: stub, resources: NewResourceScope()}
+	activeContexts.Inc()
 
 	return nil
 }
@@ -79,7 +108,47 @@ func (store *ContextStore) Remove(channelID string, txID string) error {
 		txID,
 	}
 
-	log.Printf("[host] Removing stub for context chid %s txid %s\n", key.channelID, key.txID)
+	logger.Debug("Removing stub", key.fields()...)
+
+	store.Lock()
+
+	txn, ok := store.stubs[key]
+	if !ok {
+		store.Unlock()
+		return fmt.Errorf("No stub found for transaction context %s %s", key.channelID, key.txID)
+	}
+
+	delete(store.stubs, key)
+	activeContexts.Dec()
+
+	if store.draining && len(store.stubs) == 0 {
+		store.drainedOnce.Do(func() { close(store.drained) })
+	}
+
+	store.Unlock()
+
+	// Resources still held by the guest are released once the transaction has
+	// been removed, so that they cannot be used again
+	for _, leak := range txn.resources.Teardown() {
+		fields := append(key.fields(), zap.String("resourceType", leak.Type), zap.String("resource", leak.ID))
+		if !leak.Owned {
+			logger.Warn("Guest did not release resource", fields...)
+		}
+		if leak.Err != nil {
+			logger.Warn("Error releasing resource", append(fields, zap.Error(leak.Err))...)
+		}
+	}
+
+	return nil
+}
+
+// SetReadOnly marks the specified transaction as read-only, so that write
+// operations are rejected
+func (store *ContextStore) SetReadOnly(channelID string, txID string, function string) error {
+	key := stubKey{
+		channelID,
+		txID,
+	}
 
 	store.Lock()
 	defer store.Unlock()
@@ -88,7 +157,107 @@ func (store *ContextStore) Remove(channelID string, txID string) error {
 		return fmt.Errorf("No stub found for transaction context %s %s", key.channelID, key.txID)
 	}
 
-	delete(store.stubs, key)
+	store.stubs[key].readOnlyFunction = function
 
 	return nil
 }
+
+// ReadOnly returns the name of the transaction function, and true, if the
+// specified transaction is read-only
+func (store *ContextStore) ReadOnly(context *contract.TransactionContext) (string, bool) {
+	key := stubKey{
+		channelID: context.GetChannelId(),
+		txID:      context.GetTransactionId(),
+	}
+
+	store.RLock()
+	defer store.RUnlock()
+
+	if _, ok := store.stubs[key]; !ok {
+		return "", false
+	}
+
+	function := store.stubs[key].readOnlyFunction
+
+	return function, function != ""
+}
+
+// WriteSet returns the pending writes for the specified transaction, which
+// are only recorded once this has been called. The write set is held in the
+// transaction's resource scope, and is released when the transaction ends
+func (store *ContextStore) WriteSet(context *contract.TransactionContext) (*WriteSet, error) {
+	key := stubKey{
+		channelID: context.GetChannelId(),
+		txID:      context.GetTransactionId(),
+	}
+
+	store.Lock()
+	defer store.Unlock()
+
+	txn, ok := store.stubs[key]
+	if !ok {
+		return nil, fmt.Errorf("No stub found for transaction context %s %s", key.channelID, key.txID)
+	}
+
+	if txn.writes == nil {
+		writes := NewWriteSet()
+		_, err := txn.resources.Hold(ResourceWriteSet, writes)
+		if err != nil {
+			return nil, err
+		}
+		txn.writes = writes
+	}
+
+	return txn.writes, nil
+}
+
+// Resources returns the resource scope for the specified transaction
+func (store *ContextStore) Resources(context *contract.TransactionContext) (*ResourceScope, error) {
+	key := stubKey{
+		channelID: context.GetChannelId(),
+		txID:      context.GetTransactionId(),
+	}
+
+	store.RLock()
+	defer store.RUnlock()
+
+	if _, ok := store.stubs[key]; !ok {
+		return nil, fmt.Errorf("No stub found for transaction context %s %s", key.channelID, key.txID)
+	}
+
+	return store.stubs[key].resources, nil
+}
+
+// Count returns the number of transaction contexts in the context store
+func (store *ContextStore) Count() int {
+	store.RLock()
+	defer store.RUnlock()
+
+	return len(store.stubs)
+}
+
+// Draining returns true once the context store has started draining
+func (store *ContextStore) Draining() bool {
+	store.RLock()
+	defer store.RUnlock()
+
+	return store.draining
+}
+
+// Drain stops new transaction contexts being added to the context store, and
+// waits for in-flight transactions to complete, or the context to be done
+func (store *ContextStore) Drain(ctx context.Context) error {
+	store.Lock()
+	store.draining = true
+	if len(store.stubs) == 0 {
+		store.drainedOnce.Do(func() { close(store.drained) })
+	}
+	store.Unlock()
+
+	select {
+	case <-store.drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Stopped waiting for %d in-flight transactions: %s", store.Count(), ctx.Err().Error())
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+)
+
+var _ = Describe("ContextStore", func() {
+	var contextStore *internal.ContextStore
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+	})
+
+	Describe("Drain", func() {
+		It("should return immediately when there are no in-flight transactions", func() {
+			Expect(contextStore.Drain(context.Background())).To(Succeed())
+			Expect(contextStore.Draining()).To(BeTrue())
+		})
+
+		It("should reject new transactions", func() {
+			Expect(contextStore.Drain(context.Background())).To(Succeed())
+
+			err := contextStore.Put("channel1", "draintxn1", &fakes.ChaincodeStubInterface{})
+			Expect(err).To(MatchError("Wasm chaincode is shutting down, rejecting transaction context channel1 draintxn1"))
+			Expect(contextStore.Count()).To(Equal(0))
+		})
+
+		It("should wait for in-flight transactions to complete", func() {
+			Expect(contextStore.Put("channel1", "draintxn2", &fakes.ChaincodeStubInterface{})).To(Succeed())
+
+			drained := make(chan error)
+			go func() {
+				drained <- contextStore.Drain(context.Background())
+			}()
+			Consistently(drained, 50*time.Millisecond).ShouldNot(Receive())
+
+			Expect(contextStore.Remove("channel1", "draintxn2")).To(Succeed())
+			Eventually(drained).Should(Receive(BeNil()))
+		})
+
+		It("should stop waiting when the context is done", func() {
+			Expect(contextStore.Put("channel1", "draintxn3", &fakes.ChaincodeStubInterface{})).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			err := contextStore.Drain(ctx)
+			Expect(err).To(MatchError("Stopped waiting for 1 in-flight transactions: context deadline exceeded"))
+
+			Expect(contextStore.Remove("channel1", "draintxn3")).To(Succeed())
+		})
+	})
+})
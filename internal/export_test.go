@@ -0,0 +1,17 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+// WasmInstance is a waPC instance which can be faked in tests
+type WasmInstance = wasmInstance
+
+// NewTestWasmGuest returns a WasmGuest with a pool of instances created by
+// newInstance, for tests which do not load a Wasm module
+func NewTestWasmGuest(config PoolConfig, newInstance func() WasmInstance) (*WasmGuest, error) {
+	return newWasmGuest(config, func() (*guestInstance, error) {
+		gi := &guestInstance{instance: newInstance()}
+		gi.setTransaction(nil)
+		return gi, nil
+	})
+}
@@ -6,17 +6,26 @@ package internal
 import (
 	"context"
 	"fmt"
-	"log"
 	"runtime/debug"
+	"time"
 
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
 	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
 )
 
 // FabricProxy routes calls from Wasm contract to the correct Fabric stub
 type FabricProxy struct {
 	contextStore *ContextStore
+	capabilities *Capabilities
+	guestLogger  *GuestLogger
+	hasher       *StateHasher
+	mspID        string
+	collections  *CollectionConfigs
 }
 
 // NewFabricProxy returns a new proxy to handle calls to the Fabric contract API
@@ -27,63 +36,234 @@ func NewFabricProxy(contextStore *ContextStore) *FabricProxy {
 	return &proxy
 }
 
+// SetCapabilities sets the capabilities negotiated with the Wasm guest, which
+// must be done before any transactions are invoked
+func (proxy *FabricProxy) SetCapabilities(capabilities *Capabilities) {
+	proxy.capabilities = capabilities
+}
+
+// HasCapability returns true if the named capability was negotiated with the
+// Wasm guest
+func (proxy *FabricProxy) HasCapability(name string) bool {
+	return proxy.capabilities.Has(name)
+}
+
+// SetGuestLogger sets the logger used for messages from the Wasm guest
+func (proxy *FabricProxy) SetGuestLogger(guestLogger *GuestLogger) {
+	proxy.guestLogger = guestLogger
+}
+
+// SetStateHasher sets the hasher used for world state GetHash calls, otherwise
+// the default hash algorithm is used
+func (proxy *FabricProxy) SetStateHasher(hasher *StateHasher) {
+	proxy.hasher = hasher
+}
+
+// SetMSPID sets the MSP ID of the peer organisation, which is used for the
+// symbolic implicit collection name
+func (proxy *FabricProxy) SetMSPID(mspID string) {
+	proxy.mspID = mspID
+}
+
+// ConsoleLog is the waPC console logger for the Wasm guest, for messages
+// logged while running the specified transaction, which may be nil
+func (proxy *FabricProxy) ConsoleLog(context *contract.TransactionContext, msg string) {
+	if proxy.guestLogger == nil {
+		if context.GetTransactionId() != "" {
+			logger.Info(msg, contextFields(context)...)
+		} else {
+			logger.Info(msg)
+		}
+		return
+	}
+
+	proxy.guestLogger.ConsoleLog(context, msg)
+}
+
 // FabricCall is the waPC HostCall function for interacting with the ledger
 func (proxy *FabricProxy) FabricCall(ctx context.Context, binding, namespace, operation string, payload []byte) (result []byte, err error) {
 	// Route the payload to any custom functionality accordingly.
 	// You can even route to other waPC modules!!!
-	log.Printf("[host] bd %s ns %s op %s payload length %d\n", binding, namespace, operation, len(payload))
+	logger.Debug("FabricCall", zap.String("binding", binding), zap.String("namespace", namespace), zap.String("operation", operation), zap.Int("payloadLength", len(payload)))
+
+	// Encode errors for guests which negotiated error codes, after they have
+	// been logged and recorded
+	defer func() {
+		if err != nil && proxy.HasCapability(CapabilityErrorCodes) {
+			err = encodeError(binding, err)
+		}
+	}()
+
+	// The channel and transaction ID are added from the request by each
+	// operation, so only the function is taken from the guest operation
+	attributes := append([]label.KeyValue{OperationAttribute.String(operation)}, functionAttributes(ctx)...)
+	ctx, span := tracer().Start(ctx, namespace+"."+operation, trace.WithAttributes(attributes...))
+	start := time.Now()
+	defer func() {
+		endSpan(span, err)
+		namespaceLabel, operationLabel := hostCallLabels(namespace, operation)
+		observeDuration(fabricCallDuration.WithLabelValues(namespaceLabel, operationLabel, outcome(err)), start)
+
+		if err != nil {
+			logger.Debug("FabricCall failed", zap.String("operation", operation), zap.Duration("duration", time.Since(start)), zap.Error(err))
+		} else {
+			logger.Debug("FabricCall complete", zap.String("operation", operation), zap.Duration("duration", time.Since(start)))
+		}
+	}()
 
 	// Need to recover from any panics in FabricCall otherwise the chaincode
 	// exits and, since this is being called by the Wasm guest code which was
 	// itself called by the Wasm host, it's difficult to work out why
 	defer func() {
 		if r := recover(); r != nil {
-			log.Printf("[host] Recovering from panic in FabricCall: %v \nStack: %s \n", r, string(debug.Stack()))
+			logger.Error("Recovering from panic in FabricCall", zap.String("operation", operation), zap.Any("panic", r), zap.String("stack", string(debug.Stack())))
 			err = fmt.Errorf("Operation panicked: %s %s %s", binding, namespace, operation)
 		}
 	}()
 
+	if binding == jsonBinding && proxy.HasCapability(CapabilityJSON) {
+		return proxy.jsonCall(ctx, namespace, operation, payload)
+	}
+
+	return proxy.call(ctx, binding, namespace, operation, payload)
+}
+
+// call routes a host call to the handler for the operation
+func (proxy *FabricProxy) call(ctx context.Context, binding, namespace, operation string, payload []byte) ([]byte, error) {
 	if binding == "wapc" && namespace == "LedgerService" {
 		switch operation {
 		case "CreateState":
-			log.Printf("[host] Processing CreateStateRequest...\n")
-			return proxy.createState(payload)
+			return proxy.createState(ctx, payload)
 		case "ReadState":
-			log.Printf("[host] Processing ReadStateRequest...\n")
-			return proxy.readState(payload)
+			return proxy.readState(ctx, payload)
+		case "ReadOptionalState":
+			return proxy.readOptionalState(ctx, payload)
 		case "ExistsState":
-			log.Printf("[host] Processing ExistsStateRequest...\n")
-			return proxy.existsState(payload)
+			return proxy.existsState(ctx, payload)
 		case "UpdateState":
-			log.Printf("[host] Processing UpdateStateRequest...\n")
-			return proxy.updateState(payload)
+			return proxy.updateState(ctx, payload)
+		case "PutState":
+			return proxy.putState(ctx, payload)
+		case "DeleteState":
+			return proxy.deleteState(ctx, payload)
 		case "GetHash":
-			log.Printf("[host] Processing GetHash...\n")
-			return proxy.getHash(payload)
+			return proxy.getHash(ctx, payload)
 		case "GetStates":
-			log.Printf("[host] Processing GetStatesRequest...\n")
-			return proxy.getStates(payload)
+			return proxy.getStates(ctx, payload)
+		case "OpenStatesIterator":
+			return proxy.openStatesIterator(ctx, payload)
+		case "IteratorNext":
+			return proxy.iteratorNext(ctx, payload)
+		case "IteratorHasNext":
+			return proxy.iteratorHasNext(ctx, payload)
+		case "CloseIterator":
+			return proxy.closeIterator(ctx, payload)
+		case "GetCollectionConfig":
+			return proxy.getCollectionConfig(payload)
+		case "IsCollectionMember":
+			return proxy.isCollectionMember(ctx, payload)
+		case "GetMSPID":
+			return proxy.getMSPID(payload)
+		case "GetTransient":
+			return proxy.getTransient(ctx, payload)
+		case "GetMultipleStates":
+			return proxy.getMultipleStates(ctx, payload)
+		case "BatchRequest":
+			return proxy.batchRequest(ctx, payload)
 		}
 	}
 
-	return nil, fmt.Errorf("Operation not supported: %s %s %s", binding, namespace, operation)
+	if binding == "wapc" && namespace == "LedgerService" && proxy.HasCapability(CapabilityReadYourWrites) {
+		switch operation {
+		case "GetWriteSet":
+			return proxy.getWriteSet(payload)
+		}
+	}
+
+	if binding == "wapc" && namespace == "LedgerService" && proxy.HasCapability(CapabilityPurgePrivateData) {
+		switch operation {
+		case "PurgeState":
+			return proxy.purgeState(ctx, payload)
+		}
+	}
+
+	if binding == "wapc" && namespace == "LogService" && proxy.HasCapability(CapabilityLogging) && proxy.guestLogger != nil {
+		switch operation {
+		case "Log":
+			return proxy.log(ctx, payload)
+		}
+	}
+
+	return nil, newHostCallError(hostapi.ErrorCode_NOT_SUPPORTED, "Operation not supported: %s %s %s", binding, namespace, operation)
+}
+
+// transactionStub returns the stub for a transaction context
+func (proxy *FabricProxy) transactionStub(context *contract.TransactionContext) (shim.ChaincodeStubInterface, error) {
+	stub, err := proxy.contextStore.Get(context)
+	if err != nil {
+		return nil, newHostCallError(hostapi.ErrorCode_INVALID_CONTEXT, "%s", err.Error())
+	}
+
+	return stub, nil
 }
 
-func (proxy *FabricProxy) createState(payload []byte) ([]byte, error) {
+// ledger returns the view of the ledger for a transaction context, which
+// reflects pending writes if the ReadYourWrites capability was negotiated
+func (proxy *FabricProxy) ledger(context *contract.TransactionContext) (*ledgerView, error) {
+	stub, err := proxy.transactionStub(context)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &ledgerView{stub: stub, mspID: proxy.mspID}
+	if proxy.HasCapability(CapabilityReadYourWrites) {
+		view.writes, err = proxy.contextStore.WriteSet(context)
+		if err != nil {
+			return nil, newHostCallError(hostapi.ErrorCode_INVALID_CONTEXT, "%s", err.Error())
+		}
+	}
+
+	return view, nil
+}
+
+// checkWritable returns an error if the transaction has been declared
+// read-only, to catch contract bugs which would otherwise cause endorsement
+// mismatches when the transaction is evaluated as a query
+func (proxy *FabricProxy) checkWritable(context *contract.TransactionContext) error {
+	function, readOnly := proxy.contextStore.ReadOnly(context)
+	if readOnly {
+		logger.Warn("Write rejected in read-only transaction", append(contextFields(context), zap.String("function", function))...)
+		return newHostCallError(hostapi.ErrorCode_PERMISSION_DENIED, "Transaction %s is read-only and cannot write to the ledger", function)
+	}
+
+	return nil
+}
+
+func (proxy *FabricProxy) createState(ctx context.Context, payload []byte) ([]byte, error) {
 	request := &contract.CreateStateRequest{}
 	err := proto.Unmarshal(payload, request)
 	if err != nil {
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
+	return nil, proxy.handleCreateState(ctx, request)
+}
+
+func (proxy *FabricProxy) handleCreateState(ctx context.Context, request *contract.CreateStateRequest) error {
 	context := request.GetContext()
 	state := request.GetState()
 	stateKey := state.GetKey()
-	log.Printf("[host] CreateState txid %s chid %s key %s value length %d\n", context.TransactionId, context.ChannelId, stateKey, len(state.Value))
+	logger.Debug("CreateState", append(contextFields(context), zap.String("key", stateKey), zap.Int("valueLength", len(state.GetValue())))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return wrapOperationError("CreateState", "", stateKey, err)
+	}
+
+	err = proxy.checkWritable(context)
 	if err != nil {
-		return nil, fmt.Errorf("CreateState failed: %s", err.Error())
+		return wrapOperationError("CreateState", "", stateKey, err)
 	}
 
 	collection := request.GetCollection()
@@ -92,52 +272,61 @@ func (proxy *FabricProxy) createState(payload []byte) ([]byte, error) {
 
 		stateBytes, err := stub.GetPrivateData(collectionName, stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("CreateState failed for collection %s: %s", collectionName, err.Error())
+			return wrapOperationError("CreateState", collectionName, stateKey, err)
 		}
 
 		if stateBytes != nil {
-			return nil, fmt.Errorf("CreateState failed for collection %s: State already exists for key %s", collectionName, stateKey)
+			return newOperationError(hostapi.ErrorCode_ALREADY_EXISTS, "CreateState", collectionName, stateKey, "State already exists for key %s", stateKey)
 		}
 
 		err = stub.PutPrivateData(collectionName, stateKey, state.GetValue())
 		if err != nil {
-			return nil, fmt.Errorf("CreateState failed for collection %s: %s", collectionName, err.Error())
+			return wrapOperationError("CreateState", collectionName, stateKey, err)
 		}
 	} else {
 		stateBytes, err := stub.GetState(stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("CreateState failed: %s", err.Error())
+			return wrapOperationError("CreateState", "", stateKey, err)
 		}
 
 		if stateBytes != nil {
-			return nil, fmt.Errorf("CreateState failed: State already exists for key %s", stateKey)
+			return newOperationError(hostapi.ErrorCode_ALREADY_EXISTS, "CreateState", "", stateKey, "State already exists for key %s", stateKey)
 		}
 
 		err = stub.PutState(stateKey, state.GetValue())
 		if err != nil {
-			return nil, fmt.Errorf("CreateState failed: %s", err.Error())
+			return wrapOperationError("CreateState", "", stateKey, err)
 		}
 	}
 
-	log.Printf("[host] CreateState done")
-	return nil, nil
+	return nil
 }
 
-func (proxy *FabricProxy) updateState(payload []byte) ([]byte, error) {
+func (proxy *FabricProxy) updateState(ctx context.Context, payload []byte) ([]byte, error) {
 	request := &contract.UpdateStateRequest{}
 	err := proto.Unmarshal(payload, request)
 	if err != nil {
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
+	return nil, proxy.handleUpdateState(ctx, request)
+}
+
+func (proxy *FabricProxy) handleUpdateState(ctx context.Context, request *contract.UpdateStateRequest) error {
 	context := request.GetContext()
 	state := request.GetState()
 	stateKey := state.GetKey()
-	log.Printf("[host] UpdateState txid %s chid %s key %s value length %d\n", context.TransactionId, context.ChannelId, stateKey, len(state.Value))
+	logger.Debug("UpdateState", append(contextFields(context), zap.String("key", stateKey), zap.Int("valueLength", len(state.GetValue())))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return wrapOperationError("UpdateState", "", stateKey, err)
+	}
+
+	err = proxy.checkWritable(context)
 	if err != nil {
-		return nil, fmt.Errorf("UpdateState failed: %s", err.Error())
+		return wrapOperationError("UpdateState", "", stateKey, err)
 	}
 
 	collection := request.GetCollection()
@@ -146,102 +335,310 @@ func (proxy *FabricProxy) updateState(payload []byte) ([]byte, error) {
 
 		stateBytes, err := stub.GetPrivateData(collectionName, stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("UpdateState failed for collection %s: %s", collectionName, err.Error())
+			return wrapOperationError("UpdateState", collectionName, stateKey, err)
 		}
 
 		if stateBytes == nil {
-			return nil, fmt.Errorf("UpdateState failed for collection %s: No state exists for key %s", collectionName, stateKey)
+			return newOperationError(hostapi.ErrorCode_NOT_FOUND, "UpdateState", collectionName, stateKey, "No state exists for key %s", stateKey)
 		}
 
 		err = stub.PutPrivateData(collectionName, stateKey, state.GetValue())
 		if err != nil {
-			return nil, fmt.Errorf("UpdateState failed for collection %s: %s", collectionName, err.Error())
+			return wrapOperationError("UpdateState", collectionName, stateKey, err)
 		}
 	} else {
 		stateBytes, err := stub.GetState(stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("UpdateState failed: %s", err.Error())
+			return wrapOperationError("UpdateState", "", stateKey, err)
 		}
 
 		if stateBytes == nil {
-			return nil, fmt.Errorf("UpdateState failed: No state exists for key %s", stateKey)
+			return newOperationError(hostapi.ErrorCode_NOT_FOUND, "UpdateState", "", stateKey, "No state exists for key %s", stateKey)
 		}
 
 		err = stub.PutState(stateKey, state.GetValue())
 		if err != nil {
-			return nil, fmt.Errorf("UpdateState failed: %s", err.Error())
+			return wrapOperationError("UpdateState", "", stateKey, err)
 		}
 	}
 
-	log.Printf("[host] UpdateState done")
-	return nil, nil
+	return nil
 }
 
-func (proxy *FabricProxy) readState(payload []byte) ([]byte, error) {
-	request := &contract.ReadStateRequest{}
+func (proxy *FabricProxy) putState(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.PutStateRequest{}
 	err := proto.Unmarshal(payload, request)
 	if err != nil {
-		return nil, err
+		return nil, invalidRequest(err)
+	}
+
+	return nil, proxy.handlePutState(ctx, request)
+}
+
+// handlePutState writes a state without checking whether it already exists,
+// so that the key is not added to the transaction read set
+func (proxy *FabricProxy) handlePutState(ctx context.Context, request *hostapi.PutStateRequest) error {
+	context := request.GetContext()
+	state := request.GetState()
+	stateKey := state.GetKey()
+	logger.Debug("PutState", append(contextFields(context), zap.String("key", stateKey), zap.Int("valueLength", len(state.GetValue())))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
+
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return wrapOperationError("PutState", "", stateKey, err)
+	}
+
+	err = proxy.checkWritable(context)
+	if err != nil {
+		return wrapOperationError("PutState", "", stateKey, err)
+	}
+
+	collection := request.GetCollection()
+	if collection != nil && collection.GetName() != "" {
+		collectionName := collection.GetName()
+
+		err = stub.PutPrivateData(collectionName, stateKey, state.GetValue())
+		if err != nil {
+			return wrapOperationError("PutState", collectionName, stateKey, err)
+		}
+	} else {
+		err = stub.PutState(stateKey, state.GetValue())
+		if err != nil {
+			return wrapOperationError("PutState", "", stateKey, err)
+		}
+	}
+
+	return nil
+}
+
+func (proxy *FabricProxy) deleteState(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &contract.DeleteStateRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
 	}
 
+	return nil, proxy.handleDeleteState(ctx, request)
+}
+
+func (proxy *FabricProxy) handleDeleteState(ctx context.Context, request *contract.DeleteStateRequest) error {
 	context := request.GetContext()
 	stateKey := request.GetStateKey()
-	log.Printf("[host] ReadState txid %s chid %s key %s\n", context.TransactionId, context.ChannelId, request.StateKey)
+	logger.Debug("DeleteState", append(contextFields(context), zap.String("key", stateKey))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.ledger(context)
 	if err != nil {
-		return nil, fmt.Errorf("ReadState failed: %s", err.Error())
+		return wrapOperationError("DeleteState", "", stateKey, err)
 	}
 
-	response := &contract.ReadStateResponse{}
-	state := &contract.State{}
+	err = proxy.checkWritable(context)
+	if err != nil {
+		return wrapOperationError("DeleteState", "", stateKey, err)
+	}
 
-	var stateBytes []byte
 	collection := request.GetCollection()
 	if collection != nil && collection.GetName() != "" {
 		collectionName := collection.GetName()
 
-		stateBytes, err = stub.GetPrivateData(collectionName, stateKey)
+		stateBytes, err := stub.GetPrivateData(collectionName, stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("ReadState failed for collection %s: %s", collectionName, err.Error())
+			return wrapOperationError("DeleteState", collectionName, stateKey, err)
 		}
 
 		if stateBytes == nil {
-			return nil, fmt.Errorf("ReadState failed for collection %s: State %s does not exist", collectionName, stateKey)
+			return newOperationError(hostapi.ErrorCode_NOT_FOUND, "DeleteState", collectionName, stateKey, "No state exists for key %s", stateKey)
+		}
+
+		err = stub.DelPrivateData(collectionName, stateKey)
+		if err != nil {
+			return wrapOperationError("DeleteState", collectionName, stateKey, err)
 		}
 	} else {
-		stateBytes, err = stub.GetState(stateKey)
+		stateBytes, err := stub.GetState(stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("ReadState failed: %s", err.Error())
+			return wrapOperationError("DeleteState", "", stateKey, err)
 		}
 
 		if stateBytes == nil {
-			return nil, fmt.Errorf("ReadState failed: State %s does not exist", stateKey)
+			return newOperationError(hostapi.ErrorCode_NOT_FOUND, "DeleteState", "", stateKey, "No state exists for key %s", stateKey)
 		}
+
+		err = stub.DelState(stateKey)
+		if err != nil {
+			return wrapOperationError("DeleteState", "", stateKey, err)
+		}
+	}
+
+	return nil
+}
+
+func (proxy *FabricProxy) purgeState(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.PurgeStateRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	stateKey := request.GetStateKey()
+	logger.Debug("PurgeState", append(contextFields(context), zap.String("key", stateKey))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
+
+	collectionName := request.GetCollection().GetName()
+	if collectionName == "" {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, "PurgeState", "", stateKey, "Operation not supported for world state")
+	}
+
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return nil, wrapOperationError("PurgeState", "", stateKey, err)
+	}
+
+	err = proxy.checkWritable(context)
+	if err != nil {
+		return nil, wrapOperationError("PurgeState", "", stateKey, err)
+	}
+
+	supported, err := stub.PurgePrivateData(collectionName, stateKey)
+	if err != nil {
+		return nil, wrapOperationError("PurgeState", collectionName, stateKey, err)
+	}
+
+	if !supported {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, "PurgeState", collectionName, stateKey, "Purging private data is not supported by the Fabric shim")
+	}
+
+	return nil, nil
+}
+
+func (proxy *FabricProxy) readState(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &contract.ReadStateRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
 	}
 
-	state.Key = stateKey
-	state.Value = stateBytes
-	response.State = state
+	response, err := proxy.handleReadState(ctx, request)
+	if err != nil {
+		return nil, err
+	}
 
-	log.Printf("[host] Read State done\n")
 	return proto.Marshal(response)
 }
 
-func (proxy *FabricProxy) existsState(payload []byte) ([]byte, error) {
+func (proxy *FabricProxy) handleReadState(ctx context.Context, request *contract.ReadStateRequest) (*contract.ReadStateResponse, error) {
+	stateKey := request.GetStateKey()
+	stateBytes, err := proxy.readStateValue(ctx, "ReadState", request)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateBytes == nil {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, "ReadState", request.GetCollection().GetName(), stateKey, "State %s does not exist", stateKey)
+	}
+
+	response := &contract.ReadStateResponse{
+		State: &contract.State{
+			Key:   stateKey,
+			Value: stateBytes,
+		},
+	}
+
+	return response, nil
+}
+
+func (proxy *FabricProxy) readOptionalState(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &contract.ReadStateRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	response, err := proxy.handleReadOptionalState(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(response)
+}
+
+// handleReadOptionalState reads a state like ReadState, but returns a response
+// marked as not found, instead of an error, if the state does not exist
+func (proxy *FabricProxy) handleReadOptionalState(ctx context.Context, request *contract.ReadStateRequest) (*hostapi.ReadOptionalStateResponse, error) {
+	stateBytes, err := proxy.readStateValue(ctx, "ReadOptionalState", request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &hostapi.ReadOptionalStateResponse{}
+	if stateBytes != nil {
+		response.Exists = true
+		response.State = &contract.State{
+			Key:   request.GetStateKey(),
+			Value: stateBytes,
+		}
+	}
+
+	return response, nil
+}
+
+// readStateValue returns the value of a state, or nil if it does not exist
+func (proxy *FabricProxy) readStateValue(ctx context.Context, operation string, request *contract.ReadStateRequest) ([]byte, error) {
+	context := request.GetContext()
+	stateKey := request.GetStateKey()
+	logger.Debug(operation, append(contextFields(context), zap.String("key", stateKey))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
+
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return nil, wrapOperationError(operation, "", stateKey, err)
+	}
+
+	var stateBytes []byte
+	collection := request.GetCollection()
+	if collection != nil && collection.GetName() != "" {
+		collectionName := collection.GetName()
+
+		stateBytes, err = stub.GetPrivateData(collectionName, stateKey)
+		if err != nil {
+			return nil, wrapOperationError(operation, collectionName, stateKey, err)
+		}
+	} else {
+		stateBytes, err = stub.GetState(stateKey)
+		if err != nil {
+			return nil, wrapOperationError(operation, "", stateKey, err)
+		}
+	}
+
+	return stateBytes, nil
+}
+
+func (proxy *FabricProxy) existsState(ctx context.Context, payload []byte) ([]byte, error) {
 	request := &contract.ExistsStateRequest{}
 	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	response, err := proxy.handleExistsState(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) handleExistsState(ctx context.Context, request *contract.ExistsStateRequest) (*contract.ExistsStateResponse, error) {
 	context := request.GetContext()
 	stateKey := request.GetStateKey()
-	log.Printf("[host] ExistsState txid %s chid %s key %s\n", context.TransactionId, context.ChannelId, request.StateKey)
+	logger.Debug("ExistsState", append(contextFields(context), zap.String("key", stateKey))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.ledger(context)
 	if err != nil {
-		return nil, fmt.Errorf("ExistsState failed: %s", err.Error())
+		return nil, wrapOperationError("ExistsState", "", stateKey, err)
 	}
 
 	var stateBytes []byte
@@ -251,12 +648,12 @@ func (proxy *FabricProxy) existsState(payload []byte) ([]byte, error) {
 
 		stateBytes, err = stub.GetPrivateData(collectionName, stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("ExistsState failed for collection %s: %s", collectionName, err.Error())
+			return nil, wrapOperationError("ExistsState", collectionName, stateKey, err)
 		}
 	} else {
 		stateBytes, err = stub.GetState(stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("ExistsState failed: %s", err.Error())
+			return nil, wrapOperationError("ExistsState", "", stateKey, err)
 		}
 	}
 
@@ -268,24 +665,67 @@ func (proxy *FabricProxy) existsState(payload []byte) ([]byte, error) {
 		response.Exists = true
 	}
 
-	log.Printf("[host] Exists State done")
+	return response, nil
+}
+
+func (proxy *FabricProxy) getMultipleStates(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.GetMultipleStatesRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	stateKeys := request.GetStateKeys()
+	logger.Debug("GetMultipleStates", append(contextFields(context), zap.Int("keys", len(stateKeys)))...)
+	traceRequest(ctx, context, "", request.GetCollection())
+
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return nil, wrapOperationError("GetMultipleStates", "", "", err)
+	}
+
+	var values [][]byte
+	collection := request.GetCollection()
+	if collection != nil && collection.GetName() != "" {
+		collectionName := collection.GetName()
+
+		values, err = stub.GetMultiplePrivateData(collectionName, stateKeys)
+		if err != nil {
+			return nil, wrapOperationError("GetMultipleStates", collectionName, "", err)
+		}
+	} else {
+		values, err = stub.GetMultipleStates(stateKeys)
+		if err != nil {
+			return nil, wrapOperationError("GetMultipleStates", "", "", err)
+		}
+	}
+
+	response := &hostapi.GetMultipleStatesResponse{}
+	for i, value := range values {
+		if value != nil {
+			response.States = append(response.States, &contract.State{Key: stateKeys[i], Value: value})
+		}
+	}
+
 	return proto.Marshal(response)
 }
 
-func (proxy *FabricProxy) getHash(payload []byte) ([]byte, error) {
+func (proxy *FabricProxy) getHash(ctx context.Context, payload []byte) ([]byte, error) {
 	request := &contract.GetHashRequest{}
 	err := proto.Unmarshal(payload, request)
 	if err != nil {
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
 	context := request.GetContext()
 	stateKey := request.GetStateKey()
-	log.Printf("[host] GetHash txid %s chid %s key %s\n", context.TransactionId, context.ChannelId, request.StateKey)
+	logger.Debug("GetHash", append(contextFields(context), zap.String("key", stateKey))...)
+	traceRequest(ctx, context, stateKey, request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.ledger(context)
 	if err != nil {
-		return nil, fmt.Errorf("GetHash failed: %s", err.Error())
+		return nil, wrapOperationError("GetHash", "", stateKey, err)
 	}
 
 	response := &contract.GetHashResponse{}
@@ -297,35 +737,44 @@ func (proxy *FabricProxy) getHash(payload []byte) ([]byte, error) {
 
 		hashBytes, err = stub.GetPrivateDataHash(collectionName, stateKey)
 		if err != nil {
-			return nil, fmt.Errorf("GetHash failed for collection %s: %s", collectionName, err.Error())
+			return nil, wrapOperationError("GetHash", collectionName, stateKey, err)
 		}
 
 		if hashBytes == nil {
-			return nil, fmt.Errorf("GetHash failed for collection %s: State %s does not exist", collectionName, stateKey)
+			return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, "GetHash", collectionName, stateKey, "State %s does not exist", stateKey)
 		}
 	} else {
-		return nil, fmt.Errorf("GetHash failed: Operation not supported for world state")
+		stateBytes, err := stub.GetState(stateKey)
+		if err != nil {
+			return nil, wrapOperationError("GetHash", "", stateKey, err)
+		}
+
+		if stateBytes == nil {
+			return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, "GetHash", "", stateKey, "State %s does not exist", stateKey)
+		}
+
+		hashBytes = proxy.hasher.Hash(stateBytes)
 	}
 
 	response.Hash = hashBytes
 
-	log.Printf("[host] GetHash done\n")
 	return proto.Marshal(response)
 }
 
-func (proxy *FabricProxy) getStates(payload []byte) ([]byte, error) {
+func (proxy *FabricProxy) getStates(ctx context.Context, payload []byte) ([]byte, error) {
 	request := &contract.GetStatesRequest{}
 	err := proto.Unmarshal(payload, request)
 	if err != nil {
-		return nil, err
+		return nil, invalidRequest(err)
 	}
 
 	context := request.GetContext()
-	log.Printf("[host] GetStates txid %s chid %s\n", context.TransactionId, context.ChannelId)
+	logger.Debug("GetStates", contextFields(context)...)
+	traceRequest(ctx, context, "", request.GetCollection())
 
-	stub, err := proxy.contextStore.Get(context)
+	stub, err := proxy.transactionStub(context)
 	if err != nil {
-		return nil, fmt.Errorf("GetStates failed: %s", err.Error())
+		return nil, wrapOperationError("GetStates", "", "", err)
 	}
 
 	switch qt := request.Query.(type) {
@@ -333,7 +782,7 @@ func (proxy *FabricProxy) getStates(payload []byte) ([]byte, error) {
 		keyRangeQuery := request.GetByKeyRange()
 		return proxy.getStatesByKeyRange(stub, keyRangeQuery)
 	default:
-		return nil, fmt.Errorf("GetStates failed: unsupported query type %T", qt)
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, "GetStates", "", "", "unsupported query type %T", qt)
 	}
 }
 
@@ -341,7 +790,7 @@ func (proxy *FabricProxy) getStatesByKeyRange(stub shim.ChaincodeStubInterface,
 
 	resultsIterator, err := stub.GetStateByRange(query.StartKey, query.EndKey)
 	if err != nil {
-		return nil, fmt.Errorf("GetStates (ByKeyRange) failed: %s", err.Error())
+		return nil, wrapOperationError("GetStates (ByKeyRange)", "", "", err)
 	}
 	defer resultsIterator.Close()
 
@@ -350,7 +799,7 @@ func (proxy *FabricProxy) getStatesByKeyRange(stub shim.ChaincodeStubInterface,
 	for resultsIterator.HasNext() {
 		queryResponse, err := resultsIterator.Next()
 		if err != nil {
-			return nil, fmt.Errorf("GetStates (ByKeyRange) failed: %s", err.Error())
+			return nil, wrapOperationError("GetStates (ByKeyRange)", "", "", err)
 		}
 
 		state := &contract.State{}
@@ -361,6 +810,46 @@ func (proxy *FabricProxy) getStatesByKeyRange(stub shim.ChaincodeStubInterface,
 	}
 	response.States = states
 
-	log.Printf("[host] Get States (ByKeyRange) done")
 	return proto.Marshal(response)
 }
+
+func (proxy *FabricProxy) getWriteSet(payload []byte) ([]byte, error) {
+	request := &hostapi.GetWriteSetRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	logger.Debug("GetWriteSet", contextFields(context)...)
+
+	writes, err := proxy.contextStore.WriteSet(context)
+	if err != nil {
+		return nil, newOperationError(hostapi.ErrorCode_INVALID_CONTEXT, "GetWriteSet", "", "", "%s", err.Error())
+	}
+
+	response := &hostapi.GetWriteSetResponse{
+		Entries: writes.Entries(),
+	}
+
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) log(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.LogRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	// Messages are tagged with the transaction the host is running, rather
+	// than relying on the guest to include it
+	context := TransactionContextFrom(ctx)
+	if context == nil {
+		context = request.GetContext()
+	}
+
+	proxy.guestLogger.Log(request.GetLevel(), context, request.GetMessage())
+
+	return nil, nil
+}
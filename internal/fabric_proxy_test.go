@@ -5,11 +5,14 @@ package internal_test
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
 	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
 	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
 	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
@@ -686,13 +689,44 @@ var _ = Describe("FabricProxy", func() {
 					request.Collection = collection
 				})
 
-				It("should fail with an operation not supported error", func() {
+				It("should fail if the state key does not exist in the world state", func() {
 					stub := &fakes.ChaincodeStubInterface{}
 					contextStore.Put("channel1", "txn1", stub)
 
 					result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetHash", payload)
 					Expect(result).To(BeNil())
-					Expect(err).To(MatchError("GetHash failed: Operation not supported for world state"))
+					Expect(err).To(MatchError("GetHash failed: State 007 does not exist"))
+				})
+
+				It("should return the SHA-256 hash of the world state value by default", func() {
+					stub := &fakes.ChaincodeStubInterface{}
+					contextStore.Put("channel1", "txn1", stub)
+					stub.GetStateReturns([]byte("bond"), nil)
+
+					result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetHash", payload)
+					Expect(err).NotTo(HaveOccurred())
+
+					response := &contract.GetHashResponse{}
+					Expect(proto.Unmarshal(result, response)).To(Succeed())
+					Expect(hex.EncodeToString(response.GetHash())).To(Equal("f21dea74d898cfeaf836ecc99ad0331bade09711ff927365e91ada2ff4cb5caf"))
+					Expect(stub.GetPrivateDataHashCallCount()).To(Equal(0), "Should not call GetPrivateDataHash")
+				})
+
+				It("should use the configured hash algorithm", func() {
+					stub := &fakes.ChaincodeStubInterface{}
+					contextStore.Put("channel1", "txn1", stub)
+					stub.GetStateReturns([]byte("bond"), nil)
+
+					hasher, err := internal.NewStateHasher(internal.HashConfig{Algorithm: "sha512"})
+					Expect(err).NotTo(HaveOccurred())
+					proxy.SetStateHasher(hasher)
+
+					result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetHash", payload)
+					Expect(err).NotTo(HaveOccurred())
+
+					response := &contract.GetHashResponse{}
+					Expect(proto.Unmarshal(result, response)).To(Succeed())
+					Expect(response.GetHash()).To(HaveLen(64))
 				})
 			})
 
@@ -838,6 +872,569 @@ var _ = Describe("FabricProxy", func() {
 				Expect(endKey).To(Equal(""), "Should call GetStateByRange with an unspecified end key")
 			})
 		})
+
+		Context("With a DeleteState request", func() {
+			var request *contract.DeleteStateRequest
+
+			BeforeEach(func() {
+				request = &contract.DeleteStateRequest{
+					Context:  &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					StateKey: "007",
+				}
+			})
+
+			It("should delete the state from the world state", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetStateReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				Expect(proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)).To(BeNil())
+
+				Expect(stub.DelStateCallCount()).To(Equal(1), "Should call DelState once")
+				Expect(stub.DelStateArgsForCall(0)).To(Equal("007"))
+			})
+
+			It("should delete the state from a named collection", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetPrivateDataReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+
+				request.Collection = &contract.Collection{Name: "private"}
+				payload, _ := proto.Marshal(request)
+				Expect(proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)).To(BeNil())
+
+				Expect(stub.DelPrivateDataCallCount()).To(Equal(1), "Should call DelPrivateData once")
+				collection, key := stub.DelPrivateDataArgsForCall(0)
+				Expect(collection).To(Equal("private"))
+				Expect(key).To(Equal("007"))
+			})
+
+			It("should fail if the state does not exist", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(err).To(MatchError("DeleteState failed: No state exists for key 007"))
+				Expect(stub.DelStateCallCount()).To(Equal(0), "Should not call DelState")
+			})
+
+			It("should fail if the state does not exist in a named collection", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				contextStore.Put("channel1", "txn1", stub)
+
+				request.Collection = &contract.Collection{Name: "private"}
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+				Expect(stub.GetStateCallCount()).To(Equal(0), "Should not read the world state")
+				Expect(stub.DelPrivateDataCallCount()).To(Equal(0), "Should not call DelPrivateData")
+			})
+
+			It("should fail in a read-only transaction", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetStateReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+				contextStore.SetReadOnly("channel1", "txn1", "QueryCar")
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(err).To(MatchError("DeleteState failed: Transaction QueryCar is read-only and cannot write to the ledger"))
+				Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_PERMISSION_DENIED))
+				Expect(stub.DelStateCallCount()).To(Equal(0), "Should not call DelState")
+			})
+
+			It("should fail in a read-only transaction for a named collection", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetPrivateDataReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+				contextStore.SetReadOnly("channel1", "txn1", "QueryCar")
+
+				request.Collection = &contract.Collection{Name: "private"}
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_PERMISSION_DENIED))
+				Expect(stub.DelPrivateDataCallCount()).To(Equal(0), "Should not call DelPrivateData")
+			})
+
+			It("should fail if the stub fails", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetStateReturns([]byte("bond"), nil)
+				stub.DelStateReturns(errors.New("peer unavailable"))
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(err).To(MatchError("DeleteState failed: peer unavailable"))
+			})
+		})
+
+		Context("With the ReadYourWrites capability", func() {
+			var (
+				stub        *fakes.ChaincodeStubInterface
+				txContext   *contract.TransactionContext
+				ledgerState map[string][]byte
+			)
+
+			call := func(operation string, request proto.Message) ([]byte, error) {
+				payload, _ := proto.Marshal(request)
+				return proxy.FabricCall(ctx, "wapc", "LedgerService", operation, payload)
+			}
+
+			BeforeEach(func() {
+				proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityReadYourWrites))
+
+				ledgerState = map[string][]byte{"008": []byte("not bond")}
+				stub = &fakes.ChaincodeStubInterface{}
+				stub.GetStateStub = func(key string) ([]byte, error) {
+					return ledgerState[key], nil
+				}
+				contextStore.Put("channel1", "txn1", stub)
+
+				txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+			})
+
+			It("should read pending writes", func() {
+				_, err := call("CreateState", &contract.CreateStateRequest{Context: txContext, State: &contract.State{Key: "007", Value: []byte("bond")}})
+				Expect(err).NotTo(HaveOccurred())
+
+				result, err := call("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: "007"})
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &contract.ReadStateResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				Expect(response.GetState().GetValue()).To(Equal([]byte("bond")))
+
+				_, err = call("UpdateState", &contract.UpdateStateRequest{Context: txContext, State: &contract.State{Key: "007", Value: []byte("james bond")}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stub.PutStateCallCount()).To(Equal(2))
+			})
+
+			It("should reflect pending deletes", func() {
+				_, err := call("DeleteState", &contract.DeleteStateRequest{Context: txContext, StateKey: "008"})
+				Expect(err).NotTo(HaveOccurred())
+
+				result, err := call("ExistsState", &contract.ExistsStateRequest{Context: txContext, StateKey: "008"})
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &contract.ExistsStateResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				Expect(response.GetExists()).To(BeFalse())
+
+				_, err = call("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: "008"})
+				Expect(err).To(MatchError("ReadState failed: State 008 does not exist"))
+			})
+
+			It("should list the pending writes and deletes", func() {
+				call("CreateState", &contract.CreateStateRequest{Context: txContext, State: &contract.State{Key: "007", Value: []byte("bond")}})
+				call("DeleteState", &contract.DeleteStateRequest{Context: txContext, StateKey: "008"})
+
+				result, err := call("GetWriteSet", &hostapi.GetWriteSetRequest{Context: txContext})
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &hostapi.GetWriteSetResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				Expect(response.GetEntries()).To(HaveLen(2))
+				Expect(response.GetEntries()[0].GetKey()).To(Equal("007"))
+				Expect(response.GetEntries()[0].GetValue()).To(Equal([]byte("bond")))
+				Expect(response.GetEntries()[1].GetKey()).To(Equal("008"))
+				Expect(response.GetEntries()[1].GetDeleted()).To(BeTrue())
+			})
+
+			It("should not list the write set without the capability", func() {
+				proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion))
+
+				_, err := call("GetWriteSet", &hostapi.GetWriteSetRequest{Context: txContext})
+				Expect(err).To(MatchError("Operation not supported: wapc LedgerService GetWriteSet"))
+			})
+		})
+
+		Context("With a BatchRequest", func() {
+			var (
+				stub      *fakes.ChaincodeStubInterface
+				txContext *contract.TransactionContext
+				request   *hostapi.BatchRequest
+			)
+
+			batch := func() *hostapi.BatchResponse {
+				payload, _ := proto.Marshal(request)
+				result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "BatchRequest", payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &hostapi.BatchResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				return response
+			}
+
+			BeforeEach(func() {
+				stub = &fakes.ChaincodeStubInterface{}
+				stub.GetStateStub = func(key string) ([]byte, error) {
+					if key == "007" {
+						return []byte("bond"), nil
+					}
+					return nil, nil
+				}
+				contextStore.Put("channel1", "txn1", stub)
+
+				txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+				request = &hostapi.BatchRequest{
+					Items: []*hostapi.BatchItem{
+						{Request: &hostapi.BatchItem_Read{Read: &contract.ReadStateRequest{Context: txContext, StateKey: "007"}}},
+						{Request: &hostapi.BatchItem_Read{Read: &contract.ReadStateRequest{Context: txContext, StateKey: "008"}}},
+						{Request: &hostapi.BatchItem_Create{Create: &contract.CreateStateRequest{Context: txContext, State: &contract.State{Key: "009", Value: []byte("moneypenny")}}}},
+						{Request: &hostapi.BatchItem_Exists{Exists: &contract.ExistsStateRequest{Context: txContext, StateKey: "007"}}},
+					},
+				}
+			})
+
+			It("should execute every operation and return a result for each one", func() {
+				response := batch()
+				Expect(response.GetResults()).To(HaveLen(4))
+
+				results := response.GetResults()
+				Expect(results[0].GetError()).To(BeEmpty())
+				Expect(results[0].GetRead().GetState().GetValue()).To(Equal([]byte("bond")))
+				Expect(results[1].GetError()).To(Equal("ReadState failed: State 008 does not exist"))
+				Expect(results[2].GetError()).To(BeEmpty())
+				Expect(results[2].GetCreate()).NotTo(BeNil())
+				Expect(results[3].GetExists().GetExists()).To(BeTrue())
+
+				Expect(stub.PutStateCallCount()).To(Equal(1), "Should call PutState once")
+				key, value := stub.PutStateArgsForCall(0)
+				Expect(key).To(Equal("009"))
+				Expect(value).To(Equal([]byte("moneypenny")))
+			})
+
+			It("should stop at the first failure when requested", func() {
+				request.StopOnError = true
+
+				response := batch()
+				Expect(response.GetResults()).To(HaveLen(2))
+				Expect(response.GetResults()[1].GetError()).To(Equal("ReadState failed: State 008 does not exist"))
+				Expect(stub.PutStateCallCount()).To(Equal(0), "Should not call PutState")
+			})
+
+			It("should return an error result for an empty item", func() {
+				request.Items = []*hostapi.BatchItem{{}}
+
+				response := batch()
+				Expect(response.GetResults()).To(HaveLen(1))
+				Expect(response.GetResults()[0].GetError()).To(Equal("BatchRequest failed: Unsupported request type <nil>"))
+			})
+		})
+
+		Context("With a GetMultipleStates request", func() {
+			var (
+				stub    *fakes.ChaincodeStubInterface
+				request *hostapi.GetMultipleStatesRequest
+			)
+
+			getMultipleStates := func() *hostapi.GetMultipleStatesResponse {
+				payload, _ := proto.Marshal(request)
+				result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetMultipleStates", payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &hostapi.GetMultipleStatesResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				return response
+			}
+
+			BeforeEach(func() {
+				ledgerState := map[string][]byte{"007": []byte("bond"), "009": []byte("moneypenny")}
+				stub = &fakes.ChaincodeStubInterface{}
+				stub.GetStateStub = func(key string) ([]byte, error) {
+					return ledgerState[key], nil
+				}
+				stub.GetPrivateDataStub = func(collection string, key string) ([]byte, error) {
+					return ledgerState[key], nil
+				}
+
+				request = &hostapi.GetMultipleStatesRequest{
+					Context:   &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					StateKeys: []string{"007", "008", "009"},
+				}
+			})
+
+			It("should return the states which exist in the world state", func() {
+				contextStore.Put("channel1", "txn1", stub)
+
+				response := getMultipleStates()
+				Expect(response.GetStates()).To(HaveLen(2))
+				Expect(response.GetStates()[0].GetKey()).To(Equal("007"))
+				Expect(response.GetStates()[0].GetValue()).To(Equal([]byte("bond")))
+				Expect(response.GetStates()[1].GetKey()).To(Equal("009"))
+				Expect(stub.GetStateCallCount()).To(Equal(3))
+			})
+
+			It("should return the states which exist in a named collection", func() {
+				contextStore.Put("channel1", "txn1", stub)
+				request.Collection = &contract.Collection{Name: "private"}
+
+				response := getMultipleStates()
+				Expect(response.GetStates()).To(HaveLen(2))
+				Expect(stub.GetPrivateDataCallCount()).To(Equal(3))
+				collection, _ := stub.GetPrivateDataArgsForCall(0)
+				Expect(collection).To(Equal("private"))
+			})
+
+			It("should fail if a state cannot be read", func() {
+				stub.GetStateStub = nil
+				stub.GetStateReturns(nil, errors.New("peer unavailable"))
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetMultipleStates", payload)
+				Expect(err).To(MatchError("GetMultipleStates failed: peer unavailable"))
+			})
+
+			It("should reflect pending writes with the ReadYourWrites capability", func() {
+				proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityReadYourWrites))
+				contextStore.Put("channel1", "txn1", stub)
+
+				deleteRequest := &contract.DeleteStateRequest{Context: request.GetContext(), StateKey: "009"}
+				payload, _ := proto.Marshal(deleteRequest)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "DeleteState", payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := getMultipleStates()
+				Expect(response.GetStates()).To(HaveLen(1))
+				Expect(response.GetStates()[0].GetKey()).To(Equal("007"))
+			})
+		})
+
+		Context("With a ReadOptionalState request", func() {
+			var request *contract.ReadStateRequest
+
+			readOptionalState := func() *hostapi.ReadOptionalStateResponse {
+				payload, _ := proto.Marshal(request)
+				result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "ReadOptionalState", payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &hostapi.ReadOptionalStateResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				return response
+			}
+
+			BeforeEach(func() {
+				request = &contract.ReadStateRequest{
+					Context:  &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					StateKey: "007",
+				}
+			})
+
+			It("should return a state which exists", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetStateReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+
+				response := readOptionalState()
+				Expect(response.GetExists()).To(BeTrue())
+				Expect(response.GetState().GetKey()).To(Equal("007"))
+				Expect(response.GetState().GetValue()).To(Equal([]byte("bond")))
+			})
+
+			It("should return a response marked not found for a state which does not exist", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				contextStore.Put("channel1", "txn1", stub)
+
+				response := readOptionalState()
+				Expect(response.GetExists()).To(BeFalse())
+				Expect(response.GetState()).To(BeNil())
+			})
+
+			It("should read from a named collection", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetPrivateDataReturns([]byte("bond"), nil)
+				contextStore.Put("channel1", "txn1", stub)
+
+				request.Collection = &contract.Collection{Name: "private"}
+				response := readOptionalState()
+				Expect(response.GetExists()).To(BeTrue())
+				Expect(stub.GetPrivateDataCallCount()).To(Equal(1))
+			})
+
+			It("should fail if the state cannot be read", func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetStateReturns(nil, errors.New("peer unavailable"))
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "ReadOptionalState", payload)
+				Expect(err).To(MatchError("ReadOptionalState failed: peer unavailable"))
+			})
+		})
+
+		Context("With a PutState request", func() {
+			var (
+				stub    *fakes.ChaincodeStubInterface
+				request *hostapi.PutStateRequest
+			)
+
+			BeforeEach(func() {
+				stub = &fakes.ChaincodeStubInterface{}
+				contextStore.Put("channel1", "txn1", stub)
+
+				request = &hostapi.PutStateRequest{
+					Context: &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					State:   &contract.State{Key: "007", Value: []byte("bond")},
+				}
+			})
+
+			It("should write to the world state without reading it first", func() {
+				payload, _ := proto.Marshal(request)
+				Expect(proxy.FabricCall(ctx, "wapc", "LedgerService", "PutState", payload)).To(BeNil())
+
+				Expect(stub.GetStateCallCount()).To(Equal(0), "Should not call GetState")
+				Expect(stub.PutStateCallCount()).To(Equal(1), "Should call PutState once")
+				key, value := stub.PutStateArgsForCall(0)
+				Expect(key).To(Equal("007"))
+				Expect(value).To(Equal([]byte("bond")))
+			})
+
+			It("should write to a named collection without reading it first", func() {
+				request.Collection = &contract.Collection{Name: "private"}
+				payload, _ := proto.Marshal(request)
+				Expect(proxy.FabricCall(ctx, "wapc", "LedgerService", "PutState", payload)).To(BeNil())
+
+				Expect(stub.GetPrivateDataCallCount()).To(Equal(0), "Should not call GetPrivateData")
+				Expect(stub.PutPrivateDataCallCount()).To(Equal(1), "Should call PutPrivateData once")
+				collection, key, _ := stub.PutPrivateDataArgsForCall(0)
+				Expect(collection).To(Equal("private"))
+				Expect(key).To(Equal("007"))
+			})
+
+			It("should fail in a read-only transaction", func() {
+				contextStore.SetReadOnly("channel1", "txn1", "QueryCar")
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PutState", payload)
+				Expect(err).To(MatchError("PutState failed: Transaction QueryCar is read-only and cannot write to the ledger"))
+				Expect(stub.PutStateCallCount()).To(Equal(0), "Should not call PutState")
+			})
+
+			It("should fail if the stub fails", func() {
+				stub.PutStateReturns(errors.New("peer unavailable"))
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PutState", payload)
+				Expect(err).To(MatchError("PutState failed: peer unavailable"))
+			})
+		})
+
+		Context("With a PurgeState request", func() {
+			var request *hostapi.PurgeStateRequest
+
+			BeforeEach(func() {
+				proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityPurgePrivateData))
+
+				request = &hostapi.PurgeStateRequest{
+					Context:    &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					StateKey:   "007",
+					Collection: &contract.Collection{Name: "private"},
+				}
+			})
+
+			It("should purge the state if the peer supports it", func() {
+				stub := &purgingStub{ChaincodeStubInterface: &fakes.ChaincodeStubInterface{}}
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				Expect(proxy.FabricCall(ctx, "wapc", "LedgerService", "PurgeState", payload)).To(BeNil())
+				Expect(stub.purged).To(Equal([]string{"private/007"}))
+			})
+
+			It("should fail if the Fabric shim does not support purging", func() {
+				contextStore.Put("channel1", "txn1", &fakes.ChaincodeStubInterface{})
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PurgeState", payload)
+				Expect(err).To(MatchError("PurgeState failed for collection private: Purging private data is not supported by the Fabric shim"))
+				Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_SUPPORTED))
+			})
+
+			It("should not support purging without the PurgePrivateData capability", func() {
+				proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion))
+				stub := &purgingStub{ChaincodeStubInterface: &fakes.ChaincodeStubInterface{}}
+				contextStore.Put("channel1", "txn1", stub)
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PurgeState", payload)
+				Expect(err).To(MatchError("Operation not supported: wapc LedgerService PurgeState"))
+				Expect(stub.purged).To(BeEmpty())
+			})
+
+			It("should fail for the world state", func() {
+				contextStore.Put("channel1", "txn1", &fakes.ChaincodeStubInterface{})
+
+				request.Collection = nil
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PurgeState", payload)
+				Expect(err).To(MatchError("PurgeState failed: Operation not supported for world state"))
+				Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_SUPPORTED))
+			})
+
+			It("should fail in a read-only transaction", func() {
+				stub := &purgingStub{ChaincodeStubInterface: &fakes.ChaincodeStubInterface{}}
+				contextStore.Put("channel1", "txn1", stub)
+				contextStore.SetReadOnly("channel1", "txn1", "QueryCar")
+
+				payload, _ := proto.Marshal(request)
+				_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "PurgeState", payload)
+				Expect(err).To(MatchError("PurgeState failed: Transaction QueryCar is read-only and cannot write to the ledger"))
+				Expect(stub.purged).To(BeEmpty())
+			})
+		})
+
+		Context("With a GetTransient request", func() {
+			var request *hostapi.GetTransientRequest
+
+			getTransient := func() *hostapi.GetTransientResponse {
+				payload, _ := proto.Marshal(request)
+				result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetTransient", payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				response := &hostapi.GetTransientResponse{}
+				Expect(proto.Unmarshal(result, response)).To(Succeed())
+				return response
+			}
+
+			BeforeEach(func() {
+				stub := &fakes.ChaincodeStubInterface{}
+				stub.GetTransientReturns(map[string][]byte{"pin": []byte("0000")}, nil)
+				contextStore.Put("channel1", "txn1", stub)
+
+				request = &hostapi.GetTransientRequest{
+					Context: &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"},
+					Key:     "pin",
+				}
+			})
+
+			It("should return a transient data entry", func() {
+				response := getTransient()
+				Expect(response.GetExists()).To(BeTrue())
+				Expect(response.GetValue()).To(Equal([]byte("0000")))
+			})
+
+			It("should report a missing transient data entry", func() {
+				request.Key = "ssn"
+
+				response := getTransient()
+				Expect(response.GetExists()).To(BeFalse())
+				Expect(response.GetValue()).To(BeNil())
+			})
+		})
 	})
 
 })
+
+// purgingStub is a stub for a peer which supports purging private data
+type purgingStub struct {
+	*fakes.ChaincodeStubInterface
+	purged []string
+}
+
+func (stub *purgingStub) PurgePrivateData(collection string, key string) error {
+	stub.purged = append(stub.purged, collection+"/"+key)
+	return nil
+}
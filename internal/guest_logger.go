@@ -0,0 +1,123 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+)
+
+// CapabilityLogging is the capability to use the LogService host calls
+const CapabilityLogging = "Logging"
+
+// GuestLogConfig is used to configure logging for messages from the Wasm guest
+type GuestLogConfig struct {
+	// Level is the minimum level of guest messages to log, one of debug,
+	// info, warn or error
+	Level string `yaml:"level" env:"CHAINCODE_GUEST_LOG_LEVEL" usage:"minimum level of guest messages to log"`
+	// Rate is the maximum average number of guest messages logged per
+	// second, or zero for no limit
+	Rate float64 `yaml:"rate" env:"CHAINCODE_GUEST_LOG_RATE" usage:"maximum guest messages logged per second, or 0 for no limit"`
+	// Burst is the maximum number of guest messages logged at once when
+	// Rate is set
+	Burst int `yaml:"burst" env:"CHAINCODE_GUEST_LOG_BURST" usage:"maximum guest messages logged at once when rate is set"`
+}
+
+// GuestLogger logs messages from the Wasm guest using the host logger, tagged
+// with the module name and transaction context
+type GuestLogger struct {
+	moduleName string
+	level      zapcore.Level
+	limiter    *rate.Limiter
+	dropped    uint64
+}
+
+// NewGuestLogger returns a new GuestLogger for the named Wasm module
+func NewGuestLogger(moduleName string, config GuestLogConfig) (*GuestLogger, error) {
+	gl := &GuestLogger{
+		moduleName: moduleName,
+		level:      zapcore.InfoLevel,
+	}
+
+	if config.Level != "" {
+		err := gl.level.UnmarshalText([]byte(strings.ToLower(config.Level)))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid guest log level %s", config.Level)
+		}
+	}
+
+	if config.Rate < 0 || config.Burst < 0 {
+		return nil, fmt.Errorf("Invalid guest log rate limit %v/s burst %d", config.Rate, config.Burst)
+	}
+
+	if config.Rate > 0 {
+		burst := config.Burst
+		if burst == 0 {
+			burst = int(config.Rate) + 1
+		}
+		gl.limiter = rate.NewLimiter(rate.Limit(config.Rate), burst)
+	}
+
+	return gl, nil
+}
+
+// Log logs a message from the Wasm guest, if it is enabled for the
+// configured level and the rate limit has not been exceeded. Messages are
+// tagged with the module name and, if available, the transaction context
+func (gl *GuestLogger) Log(level hostapi.LogLevel, context *contract.TransactionContext, message string) {
+	zapLevel := guestLogLevel(level)
+	if !gl.level.Enabled(zapLevel) {
+		return
+	}
+
+	if gl.limiter != nil && !gl.limiter.Allow() {
+		atomic.AddUint64(&gl.dropped, 1)
+		return
+	}
+
+	fields := []zap.Field{zap.String("module", gl.moduleName)}
+	if context.GetTransactionId() != "" {
+		fields = append(fields, contextFields(context)...)
+	}
+
+	if dropped := atomic.SwapUint64(&gl.dropped, 0); dropped > 0 {
+		fields = append(fields, zap.Uint64("dropped", dropped))
+	}
+
+	if ce := logger.Check(zapLevel, message); ce != nil {
+		ce.Write(fields...)
+	}
+}
+
+// ConsoleLog is the waPC console logger, for messages logged by the Wasm guest
+// without a level, while running the specified transaction, which may be nil
+func (gl *GuestLogger) ConsoleLog(context *contract.TransactionContext, msg string) {
+	gl.Log(hostapi.LogLevel_INFO, context, msg)
+}
+
+// Dropped returns the number of messages which have been dropped due to the
+// rate limit since a message was last logged
+func (gl *GuestLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&gl.dropped)
+}
+
+func guestLogLevel(level hostapi.LogLevel) zapcore.Level {
+	switch level {
+	case hostapi.LogLevel_DEBUG:
+		return zapcore.DebugLevel
+	case hostapi.LogLevel_WARN:
+		return zapcore.WarnLevel
+	case hostapi.LogLevel_ERROR:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
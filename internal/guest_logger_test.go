@@ -0,0 +1,173 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+)
+
+var _ = Describe("GuestLogger", func() {
+	var (
+		logs      *observer.ObservedLogs
+		txContext *contract.TransactionContext
+	)
+
+	BeforeEach(func() {
+		var core zapcore.Core
+		core, logs = observer.New(zapcore.DebugLevel)
+		internal.SetLogger(zap.New(core))
+
+		txContext = &contract.TransactionContext{
+			ChannelId:     "channel1",
+			TransactionId: "txn1",
+		}
+	})
+
+	AfterEach(func() {
+		internal.SetLogger(zap.NewNop())
+	})
+
+	Describe("NewGuestLogger", func() {
+		It("should fail with an invalid level", func() {
+			_, err := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{Level: "chatty"})
+			Expect(err).To(MatchError("Invalid guest log level chatty"))
+		})
+
+		It("should fail with a negative rate", func() {
+			_, err := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{Rate: -1})
+			Expect(err).To(MatchError("Invalid guest log rate limit -1/s burst 0"))
+		})
+	})
+
+	Describe("Log", func() {
+		It("should tag messages with the module name and transaction context", func() {
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{})
+
+			guestLogger.Log(hostapi.LogLevel_WARN, txContext, "Car not found")
+
+			Expect(logs.Len()).To(Equal(1))
+			entry := logs.All()[0]
+			Expect(entry.Level).To(Equal(zapcore.WarnLevel))
+			Expect(entry.Message).To(Equal("Car not found"))
+			Expect(entry.ContextMap()).To(Equal(map[string]interface{}{
+				"module":  "fabcar",
+				"channel": "channel1",
+				"txid":    "txn1",
+			}))
+		})
+
+		It("should tag console messages with the running transaction", func() {
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{})
+
+			guestLogger.ConsoleLog(txContext, "Hello")
+
+			Expect(logs.Len()).To(Equal(1))
+			Expect(logs.All()[0].Level).To(Equal(zapcore.InfoLevel))
+			Expect(logs.All()[0].ContextMap()).To(Equal(map[string]interface{}{
+				"module":  "fabcar",
+				"channel": "channel1",
+				"txid":    "txn1",
+			}))
+		})
+
+		It("should not tag console messages outside a transaction", func() {
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{})
+
+			guestLogger.ConsoleLog(nil, "Hello")
+
+			Expect(logs.Len()).To(Equal(1))
+			Expect(logs.All()[0].ContextMap()).To(Equal(map[string]interface{}{"module": "fabcar"}))
+		})
+
+		It("should filter messages below the configured level", func() {
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{Level: "warn"})
+
+			guestLogger.Log(hostapi.LogLevel_DEBUG, txContext, "debug")
+			guestLogger.Log(hostapi.LogLevel_INFO, txContext, "info")
+			guestLogger.Log(hostapi.LogLevel_ERROR, txContext, "error")
+
+			Expect(logs.Len()).To(Equal(1))
+			Expect(logs.All()[0].Message).To(Equal("error"))
+		})
+
+		It("should drop messages which exceed the rate limit", func() {
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{Rate: 0.001, Burst: 2})
+
+			for i := 0; i < 5; i++ {
+				guestLogger.Log(hostapi.LogLevel_INFO, txContext, "spam")
+			}
+
+			Expect(logs.Len()).To(Equal(2))
+			Expect(guestLogger.Dropped()).To(Equal(uint64(3)))
+		})
+	})
+
+	Describe("FabricProxy LogService", func() {
+		var (
+			proxy   *internal.FabricProxy
+			payload []byte
+		)
+
+		BeforeEach(func() {
+			proxy = internal.NewFabricProxy(internal.NewContextStore())
+			guestLogger, _ := internal.NewGuestLogger("fabcar", internal.GuestLogConfig{})
+			proxy.SetGuestLogger(guestLogger)
+
+			request := &hostapi.LogRequest{
+				Context: txContext,
+				Level:   hostapi.LogLevel_ERROR,
+				Message: "Oops",
+			}
+			payload, _ = proto.Marshal(request)
+		})
+
+		It("should log guest messages when the Logging capability was negotiated", func() {
+			proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityLogging))
+
+			result, err := proxy.FabricCall(context.Background(), "wapc", "LogService", "Log", payload)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+			Expect(logs.FilterMessage("Oops").FilterField(zap.String("txid", "txn1")).Len()).To(Equal(1))
+		})
+
+		It("should tag guest messages with the transaction the host is running", func() {
+			proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityLogging))
+
+			request := &hostapi.LogRequest{Level: hostapi.LogLevel_ERROR, Message: "Untagged"}
+			payload, _ = proto.Marshal(request)
+			ctx := internal.WithTransactionContext(context.Background(), txContext)
+
+			_, err := proxy.FabricCall(ctx, "wapc", "LogService", "Log", payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			entries := logs.FilterMessage("Untagged").All()
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].ContextMap()).To(HaveKeyWithValue("channel", "channel1"))
+			Expect(entries[0].ContextMap()).To(HaveKeyWithValue("txid", "txn1"))
+		})
+
+		It("should tag console messages with the running transaction", func() {
+			proxy.ConsoleLog(txContext, "Hello")
+
+			Expect(logs.FilterMessage("Hello").FilterField(zap.String("txid", "txn1")).Len()).To(Equal(1))
+		})
+
+		It("should not support the LogService without the Logging capability", func() {
+			result, err := proxy.FabricCall(context.Background(), "wapc", "LogService", "Log", payload)
+			Expect(result).To(BeNil())
+			Expect(err).To(MatchError("Operation not supported: wapc LogService Log"))
+		})
+	})
+})
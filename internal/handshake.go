@@ -0,0 +1,130 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// HostABIVersion is the version of the host call ABI implemented by the host,
+// including the protobuf messages used for guest operations and host calls
+const HostABIVersion = 1
+
+// HostCapabilities lists the optional capabilities supported by the host, in
+// addition to the LedgerService host calls available to every guest
+var HostCapabilities = []string{
+	CapabilityLogging,
+	CapabilityReadYourWrites,
+	CapabilityJSON,
+	CapabilityErrorCodes,
+	CapabilityTransientData,
+	CapabilityPurgePrivateData,
+}
+
+// Capabilities are the host capabilities negotiated with a Wasm guest
+type Capabilities struct {
+	ABIVersion uint32
+	names      map[string]bool
+}
+
+// NewCapabilities returns the specified capabilities for an ABI version
+func NewCapabilities(abiVersion uint32, names ...string) *Capabilities {
+	capabilities := &Capabilities{
+		ABIVersion: abiVersion,
+		names:      make(map[string]bool),
+	}
+
+	for _, name := range names {
+		capabilities.names[name] = true
+	}
+
+	return capabilities
+}
+
+// Has returns true if the named capability was negotiated
+func (capabilities *Capabilities) Has(name string) bool {
+	if capabilities == nil {
+		return false
+	}
+
+	return capabilities.names[name]
+}
+
+// Names returns the negotiated capability names in alphabetical order
+func (capabilities *Capabilities) Names() []string {
+	names := []string{}
+	if capabilities == nil {
+		return names
+	}
+
+	for name := range capabilities.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Handshake calls the guest Handshake operation to check that the guest was
+// built for the host ABI version and exports the InvokeTransaction operation,
+// and returns the capabilities supported by both the host and the guest
+func Handshake(invoker WasmGuestInvoker) (*Capabilities, error) {
+	request := &hostapi.HandshakeRequest{
+		AbiVersion:   HostABIVersion,
+		Capabilities: HostCapabilities,
+	}
+
+	args, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("Performing handshake with Wasm guest")
+	result, err := invoker.InvokeWasmOperation(context.Background(), "Handshake", args)
+	if err != nil {
+		return nil, fmt.Errorf("Wasm module refused: Handshake operation failed, the module may have been built for an older host: %s", err.Error())
+	}
+
+	response := &hostapi.HandshakeResponse{}
+	err = proto.Unmarshal(result, response)
+	if err != nil {
+		return nil, fmt.Errorf("Wasm module refused: Invalid handshake response: %s", err.Error())
+	}
+
+	if response.GetAbiVersion() != HostABIVersion {
+		return nil, fmt.Errorf("Wasm module refused: Module was built for ABI version %d but the host supports ABI version %d", response.GetAbiVersion(), HostABIVersion)
+	}
+
+	exportsInvokeTransaction := false
+	for _, operation := range response.GetOperations() {
+		if operation == "InvokeTransaction" {
+			exportsInvokeTransaction = true
+			break
+		}
+	}
+	if !exportsInvokeTransaction {
+		return nil, fmt.Errorf("Wasm module refused: Module does not export the InvokeTransaction operation")
+	}
+
+	supported := NewCapabilities(HostABIVersion, HostCapabilities...)
+	negotiated := []string{}
+	for _, name := range response.GetCapabilities() {
+		if supported.Has(name) {
+			negotiated = append(negotiated, name)
+		} else {
+			logger.Warn("Ignoring unsupported capability requested by Wasm guest", zap.String("capability", name))
+		}
+	}
+
+	capabilities := NewCapabilities(response.GetAbiVersion(), negotiated...)
+	logger.Info("Handshake complete", zap.Uint32("abiVersion", capabilities.ABIVersion), zap.Strings("capabilities", capabilities.Names()))
+
+	return capabilities, nil
+}
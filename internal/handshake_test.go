@@ -0,0 +1,131 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+)
+
+var _ = Describe("Handshake", func() {
+	var (
+		wasmInvoker *fakes.WasmGuestInvoker
+		response    *hostapi.HandshakeResponse
+	)
+
+	BeforeEach(func() {
+		wasmInvoker = &fakes.WasmGuestInvoker{}
+
+		response = &hostapi.HandshakeResponse{
+			AbiVersion: internal.HostABIVersion,
+			Operations: []string{"Handshake", "InvokeTransaction"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		result, _ := proto.Marshal(response)
+		wasmInvoker.InvokeWasmOperationReturns(result, nil)
+	})
+
+	It("should send the host ABI version and capabilities to the guest", func() {
+		_, err := internal.Handshake(wasmInvoker)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, operation, args := wasmInvoker.InvokeWasmOperationArgsForCall(0)
+		Expect(operation).To(Equal("Handshake"))
+
+		request := &hostapi.HandshakeRequest{}
+		Expect(proto.Unmarshal(args, request)).To(Succeed())
+		Expect(request.GetAbiVersion()).To(Equal(uint32(internal.HostABIVersion)))
+		Expect(request.GetCapabilities()).To(ConsistOf(internal.HostCapabilities))
+	})
+
+	It("should return the negotiated ABI version", func() {
+		capabilities, err := internal.Handshake(wasmInvoker)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(capabilities.ABIVersion).To(Equal(uint32(internal.HostABIVersion)))
+	})
+
+	Context("When the guest requests an unsupported capability", func() {
+		BeforeEach(func() {
+			response.Capabilities = []string{"TimeTravel"}
+		})
+
+		It("should not negotiate the capability", func() {
+			capabilities, err := internal.Handshake(wasmInvoker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities.Has("TimeTravel")).To(BeFalse())
+			Expect(capabilities.Names()).To(BeEmpty())
+		})
+	})
+
+	Context("When the guest does not implement the Handshake operation", func() {
+		JustBeforeEach(func() {
+			wasmInvoker.InvokeWasmOperationReturns(nil, errors.New("no handler registered for Handshake"))
+		})
+
+		It("should refuse the module", func() {
+			_, err := internal.Handshake(wasmInvoker)
+			Expect(err).To(MatchError("Wasm module refused: Handshake operation failed, the module may have been built for an older host: no handler registered for Handshake"))
+		})
+	})
+
+	Context("When the guest was built for a different ABI version", func() {
+		BeforeEach(func() {
+			response.AbiVersion = internal.HostABIVersion + 1
+		})
+
+		It("should refuse the module", func() {
+			_, err := internal.Handshake(wasmInvoker)
+			Expect(err).To(MatchError("Wasm module refused: Module was built for ABI version 2 but the host supports ABI version 1"))
+		})
+	})
+
+	Context("When the guest does not export the InvokeTransaction operation", func() {
+		BeforeEach(func() {
+			response.Operations = []string{"Handshake"}
+		})
+
+		It("should refuse the module", func() {
+			_, err := internal.Handshake(wasmInvoker)
+			Expect(err).To(MatchError("Wasm module refused: Module does not export the InvokeTransaction operation"))
+		})
+	})
+
+	Context("When the guest returns an invalid response", func() {
+		JustBeforeEach(func() {
+			wasmInvoker.InvokeWasmOperationReturns([]byte{0xff}, nil)
+		})
+
+		It("should refuse the module", func() {
+			_, err := internal.Handshake(wasmInvoker)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("Wasm module refused: Invalid handshake response"))
+		})
+	})
+})
+
+var _ = Describe("Capabilities", func() {
+	It("should report negotiated capabilities", func() {
+		capabilities := internal.NewCapabilities(1, "B", "A")
+		Expect(capabilities.Has("A")).To(BeTrue())
+		Expect(capabilities.Has("C")).To(BeFalse())
+		Expect(capabilities.Names()).To(Equal([]string{"A", "B"}))
+	})
+
+	It("should not report capabilities before a handshake", func() {
+		proxy := internal.NewFabricProxy(internal.NewContextStore())
+		Expect(proxy.HasCapability("A")).To(BeFalse())
+
+		proxy.SetCapabilities(internal.NewCapabilities(1, "A"))
+		Expect(proxy.HasCapability("A")).To(BeTrue())
+	})
+})
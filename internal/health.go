@@ -0,0 +1,242 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultWedgedTimeout is how long every waPC instance can be in use, without
+// any being returned to the pool, before the pool is considered to be wedged
+const DefaultWedgedTimeout = 30 * time.Second
+
+// HealthConfig is used to configure the health endpoints
+type HealthConfig struct {
+	// Address is the host and port to serve the health endpoints on, or
+	// empty to disable the health endpoints
+	Address string `yaml:"address" env:"CHAINCODE_HEALTH_ADDRESS" usage:"host and port to serve health endpoints on"`
+	// WedgedTimeout is how long every waPC instance can be in use before the
+	// liveness check fails
+	WedgedTimeout time.Duration `yaml:"wedgedTimeout" env:"CHAINCODE_HEALTH_WEDGED_TIMEOUT" usage:"how long every waPC instance can be in use before the liveness check fails"`
+}
+
+// PoolStats describes the state of the waPC instance pool
+type PoolStats struct {
+	Size         int       `json:"size"`
+	InUse        int       `json:"inUse"`
+	LastReturned time.Time `json:"lastReturned"`
+}
+
+// PoolStatsReporter is the interface that wraps the PoolStats method.
+//counterfeiter:generate -o fakes/pool_stats_reporter.go --fake-name PoolStatsReporter . PoolStatsReporter
+type PoolStatsReporter interface {
+	PoolStats() PoolStats
+}
+
+// ModuleStatus describes the loaded Wasm module
+type ModuleStatus struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Hash    string `json:"hash"`
+}
+
+// HealthStatus is the response from the status endpoint
+type HealthStatus struct {
+	Ready                bool          `json:"ready"`
+	Live                 bool          `json:"live"`
+	Reason               string        `json:"reason,omitempty"`
+	Module               *ModuleStatus `json:"module,omitempty"`
+	Pool                 *PoolStats    `json:"pool,omitempty"`
+	InFlightTransactions int           `json:"inFlightTransactions"`
+	Uptime               string        `json:"uptime"`
+}
+
+// Health keeps track of whether the Wasm chaincode is ready to process
+// transactions, and whether it is still live
+type Health struct {
+	sync.RWMutex
+	contextStore  *ContextStore
+	wasmPackage   *WasmPackage
+	pool          PoolStatsReporter
+	listening     bool
+	wedgedTimeout time.Duration
+	started       time.Time
+}
+
+// NewHealth returns a new Health for the Wasm chaincode using the specified
+// context store to report in-flight transactions
+func NewHealth(contextStore *ContextStore, wedgedTimeout time.Duration) *Health {
+	if wedgedTimeout <= 0 {
+		wedgedTimeout = DefaultWedgedTimeout
+	}
+
+	return &Health{
+		contextStore:  contextStore,
+		wedgedTimeout: wedgedTimeout,
+		started:       time.Now(),
+	}
+}
+
+// SetPackage records that the Wasm package has been loaded
+func (h *Health) SetPackage(wasmPackage *WasmPackage) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.wasmPackage = wasmPackage
+}
+
+// SetPool records that the Wasm module has been compiled and the waPC instance
+// pool has been populated
+func (h *Health) SetPool(pool PoolStatsReporter) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.pool = pool
+}
+
+// SetListening records whether the chaincode server is accepting connections
+func (h *Health) SetListening(listening bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.listening = listening
+}
+
+// Ready returns an error if the Wasm chaincode is not ready to process
+// transactions
+func (h *Health) Ready() error {
+	h.RLock()
+	defer h.RUnlock()
+
+	if h.contextStore.Draining() {
+		return fmt.Errorf("Wasm chaincode is shutting down")
+	}
+
+	if h.wasmPackage == nil {
+		return fmt.Errorf("Wasm module not loaded")
+	}
+
+	if h.pool == nil || h.pool.PoolStats().Size == 0 {
+		return fmt.Errorf("Wasm instance pool not populated")
+	}
+
+	if !h.listening {
+		return fmt.Errorf("Chaincode server not listening")
+	}
+
+	return nil
+}
+
+// Live returns an error if the waPC instance pool is wedged, i.e. every
+// instance has been in use for longer than the wedged timeout
+func (h *Health) Live() error {
+	h.RLock()
+	defer h.RUnlock()
+
+	if h.pool == nil {
+		return nil
+	}
+
+	stats := h.pool.PoolStats()
+	if stats.Size > 0 && stats.InUse >= stats.Size {
+		blocked := time.Since(stats.LastReturned)
+		if blocked > h.wedgedTimeout {
+			return fmt.Errorf("Wasm instance pool wedged: all %d instances in use for %s", stats.Size, blocked.Round(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// Status returns the current status of the Wasm chaincode
+func (h *Health) Status() *HealthStatus {
+	status := &HealthStatus{
+		Ready:                true,
+		Live:                 true,
+		InFlightTransactions: h.contextStore.Count(),
+	}
+
+	if err := h.Live(); err != nil {
+		status.Live = false
+		status.Reason = err.Error()
+	}
+
+	if err := h.Ready(); err != nil {
+		status.Ready = false
+		status.Reason = err.Error()
+	}
+
+	h.RLock()
+	defer h.RUnlock()
+
+	status.Uptime = time.Since(h.started).Round(time.Second).String()
+
+	if h.wasmPackage != nil {
+		status.Module = &ModuleStatus{
+			Name: h.wasmPackage.Name(),
+			Hash: h.wasmPackage.Hash,
+		}
+		if h.wasmPackage.Manifest != nil {
+			status.Module.Version = h.wasmPackage.Manifest.Version
+		}
+	}
+
+	if h.pool != nil {
+		stats := h.pool.PoolStats()
+		status.Pool = &stats
+	}
+
+	return status
+}
+
+// Handler returns an HTTP handler for the health endpoints:
+//
+//	/livez   returns 503 if the waPC instance pool is wedged
+//	/readyz  returns 503 until the Wasm chaincode is ready to process transactions
+//	/healthz returns 503 if the Wasm chaincode is either not ready or not live
+//	/status  returns the HealthStatus as JSON
+func (h *Health) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", h.probeHandler(h.Live))
+	mux.HandleFunc("/readyz", h.probeHandler(h.Ready))
+	mux.HandleFunc("/healthz", h.probeHandler(func() error {
+		if err := h.Live(); err != nil {
+			return err
+		}
+		return h.Ready()
+	}))
+	mux.HandleFunc("/status", h.statusHandler)
+
+	return mux
+}
+
+func (h *Health) probeHandler(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		if err := check(); err != nil {
+			logger.Debug("Health check failed", zap.String("path", r.URL.Path), zap.Error(err))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, err.Error())
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+func (h *Health) statusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(h.Status())
+	if err != nil {
+		logger.Error("Error writing status", zap.Error(err))
+	}
+}
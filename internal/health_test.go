@@ -0,0 +1,146 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+)
+
+var _ = Describe("Health", func() {
+	var (
+		contextStore *internal.ContextStore
+		pool         *fakes.PoolStatsReporter
+		health       *internal.Health
+	)
+
+	probe := func(path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		health.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+
+		return recorder.Code, recorder.Body.String()
+	}
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		pool = &fakes.PoolStatsReporter{}
+		pool.PoolStatsReturns(internal.PoolStats{Size: 10, LastReturned: time.Now()})
+
+		health = internal.NewHealth(contextStore, time.Minute)
+	})
+
+	Describe("Readiness", func() {
+		It("should not be ready until the Wasm module is loaded", func() {
+			code, body := probe("/readyz")
+			Expect(code).To(Equal(503))
+			Expect(body).To(Equal("Wasm module not loaded\n"))
+		})
+
+		It("should not be ready until the pool is populated", func() {
+			health.SetPackage(&internal.WasmPackage{})
+
+			code, body := probe("/readyz")
+			Expect(code).To(Equal(503))
+			Expect(body).To(Equal("Wasm instance pool not populated\n"))
+		})
+
+		It("should not be ready until the chaincode server is listening", func() {
+			health.SetPackage(&internal.WasmPackage{})
+			health.SetPool(pool)
+
+			code, body := probe("/readyz")
+			Expect(code).To(Equal(503))
+			Expect(body).To(Equal("Chaincode server not listening\n"))
+		})
+
+		It("should be ready once the chaincode server is listening", func() {
+			health.SetPackage(&internal.WasmPackage{})
+			health.SetPool(pool)
+			health.SetListening(true)
+
+			code, body := probe("/readyz")
+			Expect(code).To(Equal(200))
+			Expect(body).To(Equal("ok\n"))
+
+			code, _ = probe("/healthz")
+			Expect(code).To(Equal(200))
+		})
+
+		It("should not be ready once the Wasm chaincode is shutting down", func() {
+			health.SetPackage(&internal.WasmPackage{})
+			health.SetPool(pool)
+			health.SetListening(true)
+			Expect(contextStore.Drain(context.Background())).To(Succeed())
+
+			code, body := probe("/readyz")
+			Expect(code).To(Equal(503))
+			Expect(body).To(Equal("Wasm chaincode is shutting down\n"))
+		})
+	})
+
+	Describe("Liveness", func() {
+		BeforeEach(func() {
+			health.SetPool(pool)
+		})
+
+		It("should be live when instances are available", func() {
+			pool.PoolStatsReturns(internal.PoolStats{Size: 10, InUse: 9, LastReturned: time.Now().Add(-time.Hour)})
+
+			code, _ := probe("/livez")
+			Expect(code).To(Equal(200))
+		})
+
+		It("should be live when every instance is in use but instances are still being returned", func() {
+			pool.PoolStatsReturns(internal.PoolStats{Size: 10, InUse: 10, LastReturned: time.Now()})
+
+			code, _ := probe("/livez")
+			Expect(code).To(Equal(200))
+		})
+
+		It("should not be live when the pool is wedged", func() {
+			pool.PoolStatsReturns(internal.PoolStats{Size: 10, InUse: 10, LastReturned: time.Now().Add(-2 * time.Minute)})
+
+			code, body := probe("/livez")
+			Expect(code).To(Equal(503))
+			Expect(body).To(HavePrefix("Wasm instance pool wedged: all 10 instances in use for 2m"))
+
+			code, _ = probe("/healthz")
+			Expect(code).To(Equal(503))
+		})
+	})
+
+	Describe("Status", func() {
+		It("should report the module, pool and in-flight transactions", func() {
+			health.SetPackage(&internal.WasmPackage{
+				Path:     "fabcar.wasm",
+				Manifest: &internal.PackageManifest{Name: "fabcar", Version: "1.0.0"},
+				Hash:     "abc123",
+			})
+			health.SetPool(pool)
+			pool.PoolStatsReturns(internal.PoolStats{Size: 10, InUse: 2, LastReturned: time.Now()})
+			contextStore.Put("channel1", "healthtxn1", &fakes.ChaincodeStubInterface{})
+
+			code, body := probe("/status")
+			Expect(code).To(Equal(200))
+
+			status := &internal.HealthStatus{}
+			Expect(json.Unmarshal([]byte(body), status)).To(Succeed())
+			Expect(status.Ready).To(BeFalse())
+			Expect(status.Live).To(BeTrue())
+			Expect(status.Reason).To(Equal("Chaincode server not listening"))
+			Expect(status.Module).To(Equal(&internal.ModuleStatus{Name: "fabcar", Version: "1.0.0", Hash: "abc123"}))
+			Expect(status.Pool.Size).To(Equal(10))
+			Expect(status.Pool.InUse).To(Equal(2))
+			Expect(status.InFlightTransactions).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CapabilityErrorCodes is the capability for failed host calls to return an
+// encoded HostError message, so that the guest can check the error code
+// instead of parsing the error message
+const CapabilityErrorCodes = "ErrorCodes"
+
+// HostCallError is an error from a host call, with a code identifying the
+// reason the host call failed
+type HostCallError struct {
+	Code       hostapi.ErrorCode
+	Message    string
+	Key        string
+	Collection string
+}
+
+func (e *HostCallError) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the code for an error from a host call, which is UNKNOWN
+// if the error does not have a code
+func ErrorCode(err error) hostapi.ErrorCode {
+	var hostCallError *HostCallError
+	if errors.As(err, &hostCallError) {
+		return hostCallError.Code
+	}
+
+	return hostapi.ErrorCode_UNKNOWN
+}
+
+func newHostCallError(code hostapi.ErrorCode, format string, args ...interface{}) *HostCallError {
+	return &HostCallError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// newOperationError returns an error for a ledger operation which failed for
+// the reason described by the format and args
+func newOperationError(code hostapi.ErrorCode, operation string, collection string, key string, format string, args ...interface{}) *HostCallError {
+	var message string
+	if collection != "" {
+		message = fmt.Sprintf("%s failed for collection %s: %s", operation, collection, fmt.Sprintf(format, args...))
+	} else {
+		message = fmt.Sprintf("%s failed: %s", operation, fmt.Sprintf(format, args...))
+	}
+
+	return &HostCallError{
+		Code:       code,
+		Message:    message,
+		Key:        key,
+		Collection: collection,
+	}
+}
+
+// wrapOperationError returns an error for a ledger operation which failed
+// because of another error, keeping the code of host call errors, and
+// otherwise assuming the error came from the peer
+func wrapOperationError(operation string, collection string, key string, err error) *HostCallError {
+	code := ErrorCode(err)
+	if code == hostapi.ErrorCode_UNKNOWN {
+		code = hostapi.ErrorCode_PEER_ERROR
+	}
+
+	return newOperationError(code, operation, collection, key, "%s", err.Error())
+}
+
+// invalidRequest returns an error for a request payload which could not be
+// decoded
+func invalidRequest(err error) *HostCallError {
+	return newHostCallError(hostapi.ErrorCode_INVALID_REQUEST, "%s", err.Error())
+}
+
+// encodedError is an error containing an encoded HostError message
+type encodedError struct {
+	payload []byte
+}
+
+func (e *encodedError) Error() string {
+	return string(e.payload)
+}
+
+// encodeError returns an error containing a HostError message for the guest,
+// encoded for the host call binding
+func encodeError(binding string, err error) error {
+	message := &hostapi.HostError{
+		Code:    ErrorCode(err),
+		Message: err.Error(),
+	}
+
+	var hostCallError *HostCallError
+	if errors.As(err, &hostCallError) {
+		message.Key = hostCallError.Key
+		message.Collection = hostCallError.Collection
+	}
+
+	var payload []byte
+	var encodeErr error
+	if binding == jsonBinding {
+		payload, encodeErr = protojson.Marshal(message)
+	} else {
+		payload, encodeErr = proto.Marshal(message)
+	}
+
+	if encodeErr != nil {
+		logger.Error("Unable to encode host error, returning the error message", zap.Error(encodeErr))
+		return err
+	}
+
+	return &encodedError{payload: payload}
+}
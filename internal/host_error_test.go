@@ -0,0 +1,134 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Host call errors", func() {
+	var (
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		stub         *fakes.ChaincodeStubInterface
+		txContext    *contract.TransactionContext
+		ctx          context.Context
+	)
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		ctx = context.Background()
+
+		stub = &fakes.ChaincodeStubInterface{}
+		contextStore.Put("channel1", "txn1", stub)
+		txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+	})
+
+	call := func(operation string, request proto.Message) error {
+		payload, _ := proto.Marshal(request)
+		_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", operation, payload)
+		return err
+	}
+
+	It("should be NOT_FOUND when reading a missing state", func() {
+		err := call("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: "007"})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+	})
+
+	It("should be ALREADY_EXISTS when creating an existing state", func() {
+		stub.GetStateReturns([]byte("bond"), nil)
+
+		err := call("CreateState", &contract.CreateStateRequest{Context: txContext, State: &contract.State{Key: "007"}})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_ALREADY_EXISTS))
+	})
+
+	It("should be PERMISSION_DENIED when writing in a read-only transaction", func() {
+		contextStore.SetReadOnly("channel1", "txn1", "QueryCar")
+
+		err := call("DeleteState", &contract.DeleteStateRequest{Context: txContext, StateKey: "007"})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_PERMISSION_DENIED))
+	})
+
+	It("should be PEER_ERROR when the stub fails", func() {
+		stub.GetStateReturns(nil, errors.New("peer unavailable"))
+
+		err := call("ExistsState", &contract.ExistsStateRequest{Context: txContext, StateKey: "007"})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_PEER_ERROR))
+	})
+
+	It("should be INVALID_CONTEXT for an unknown transaction", func() {
+		unknownContext := &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn2"}
+
+		err := call("ReadState", &contract.ReadStateRequest{Context: unknownContext, StateKey: "007"})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_INVALID_CONTEXT))
+	})
+
+	It("should be NOT_SUPPORTED for an unknown operation", func() {
+		err := call("MutateState", &contract.ReadStateRequest{})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_SUPPORTED))
+	})
+
+	It("should be INVALID_REQUEST for a payload which cannot be decoded", func() {
+		_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "ReadState", []byte{0xff})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_INVALID_REQUEST))
+	})
+
+	It("should include the error code in batch results", func() {
+		request := &hostapi.BatchRequest{
+			Items: []*hostapi.BatchItem{
+				{Request: &hostapi.BatchItem_Read{Read: &contract.ReadStateRequest{Context: txContext, StateKey: "007"}}},
+			},
+		}
+		payload, _ := proto.Marshal(request)
+
+		result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "BatchRequest", payload)
+		Expect(err).NotTo(HaveOccurred())
+
+		response := &hostapi.BatchResponse{}
+		Expect(proto.Unmarshal(result, response)).To(Succeed())
+		Expect(response.GetResults()[0].GetErrorCode()).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+	})
+
+	Context("With the ErrorCodes capability", func() {
+		BeforeEach(func() {
+			proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityErrorCodes, internal.CapabilityJSON))
+		})
+
+		It("should return an encoded HostError message", func() {
+			err := call("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: "007", Collection: &contract.Collection{Name: "private"}})
+			Expect(err).To(HaveOccurred())
+
+			hostError := &hostapi.HostError{}
+			Expect(proto.Unmarshal([]byte(err.Error()), hostError)).To(Succeed())
+			Expect(hostError.GetCode()).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+			Expect(hostError.GetMessage()).To(Equal("ReadState failed for collection private: State 007 does not exist"))
+			Expect(hostError.GetKey()).To(Equal("007"))
+			Expect(hostError.GetCollection()).To(Equal("private"))
+		})
+
+		It("should encode the HostError message as JSON for the json binding", func() {
+			payload := `{"context": {"channelId": "channel1", "transactionId": "txn1"}, "stateKey": "007"}`
+
+			_, err := proxy.FabricCall(ctx, "json", "LedgerService", "ReadState", []byte(payload))
+			Expect(err).To(HaveOccurred())
+
+			hostError := &hostapi.HostError{}
+			Expect(protojson.Unmarshal([]byte(err.Error()), hostError)).To(Succeed())
+			Expect(hostError.GetCode()).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+			Expect(hostError.GetKey()).To(Equal("007"))
+		})
+	})
+})
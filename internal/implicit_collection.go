@@ -0,0 +1,69 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"strings"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"google.golang.org/protobuf/proto"
+)
+
+// ImplicitCollectionPrefix is the prefix of the names of Fabric's implicit
+// private data collections, which is followed by an MSP ID
+const ImplicitCollectionPrefix = "_implicit_org_"
+
+// ImplicitCollection is a symbolic collection name which guests can use for
+// the implicit private data collection of the peer organisation, or of another
+// organisation by adding a colon and its MSP ID, e.g. $implicit_org:Org2MSP
+const ImplicitCollection = "$implicit_org"
+
+// ImplicitCollectionName returns the name of the implicit private data
+// collection for an organisation
+func ImplicitCollectionName(mspID string) string {
+	return ImplicitCollectionPrefix + mspID
+}
+
+// resolveCollection returns the private data collection name for a collection
+// in a request, which may be a symbolic implicit collection name
+func resolveCollection(collection string, mspID string) (string, error) {
+	if !strings.HasPrefix(collection, ImplicitCollection) {
+		return collection, nil
+	}
+
+	orgMSPID := strings.TrimPrefix(collection, ImplicitCollection)
+	switch {
+	case orgMSPID == "":
+		if mspID == "" {
+			return "", newHostCallError(hostapi.ErrorCode_NOT_SUPPORTED, "The peer MSP ID has not been configured")
+		}
+
+		return ImplicitCollectionName(mspID), nil
+	case strings.HasPrefix(orgMSPID, ":") && len(orgMSPID) > 1:
+		return ImplicitCollectionName(orgMSPID[1:]), nil
+	}
+
+	return "", newHostCallError(hostapi.ErrorCode_INVALID_REQUEST, "Invalid implicit collection %s", collection)
+}
+
+func (proxy *FabricProxy) getMSPID(payload []byte) ([]byte, error) {
+	request := &hostapi.GetMSPIDRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	logger.Debug("GetMSPID", contextFields(request.GetContext())...)
+
+	if proxy.mspID == "" {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, "GetMSPID", "", "", "The peer MSP ID has not been configured")
+	}
+
+	response := &hostapi.GetMSPIDResponse{
+		MspId:              proxy.mspID,
+		ImplicitCollection: ImplicitCollectionName(proxy.mspID),
+	}
+
+	return proto.Marshal(response)
+}
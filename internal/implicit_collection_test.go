@@ -0,0 +1,104 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Implicit collections", func() {
+	var (
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		stub         *fakes.ChaincodeStubInterface
+		txContext    *contract.TransactionContext
+		ctx          context.Context
+	)
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		proxy.SetMSPID("Org1MSP")
+		ctx = context.Background()
+
+		stub = &fakes.ChaincodeStubInterface{}
+		stub.GetPrivateDataReturns([]byte("bond"), nil)
+		contextStore.Put("channel1", "txn1", stub)
+		txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+	})
+
+	readState := func(collection string) error {
+		request := &contract.ReadStateRequest{Context: txContext, StateKey: "007", Collection: &contract.Collection{Name: collection}}
+		payload, _ := proto.Marshal(request)
+		_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "ReadState", payload)
+		return err
+	}
+
+	Describe("GetMSPID", func() {
+		It("should return the peer MSP ID and implicit collection name", func() {
+			payload, _ := proto.Marshal(&hostapi.GetMSPIDRequest{Context: txContext})
+			result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetMSPID", payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			response := &hostapi.GetMSPIDResponse{}
+			Expect(proto.Unmarshal(result, response)).To(Succeed())
+			Expect(response.GetMspId()).To(Equal("Org1MSP"))
+			Expect(response.GetImplicitCollection()).To(Equal("_implicit_org_Org1MSP"))
+		})
+
+		It("should fail if the MSP ID has not been configured", func() {
+			proxy.SetMSPID("")
+
+			payload, _ := proto.Marshal(&hostapi.GetMSPIDRequest{Context: txContext})
+			_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "GetMSPID", payload)
+			Expect(err).To(MatchError("GetMSPID failed: The peer MSP ID has not been configured"))
+		})
+	})
+
+	Describe("Symbolic collection names", func() {
+		It("should use the implicit collection for the peer organisation", func() {
+			Expect(readState("$implicit_org")).To(Succeed())
+
+			collection, _ := stub.GetPrivateDataArgsForCall(0)
+			Expect(collection).To(Equal("_implicit_org_Org1MSP"))
+		})
+
+		It("should use the implicit collection for another organisation", func() {
+			Expect(readState("$implicit_org:Org2MSP")).To(Succeed())
+
+			collection, _ := stub.GetPrivateDataArgsForCall(0)
+			Expect(collection).To(Equal("_implicit_org_Org2MSP"))
+		})
+
+		It("should not change other collection names", func() {
+			Expect(readState("private")).To(Succeed())
+
+			collection, _ := stub.GetPrivateDataArgsForCall(0)
+			Expect(collection).To(Equal("private"))
+		})
+
+		It("should fail for the peer organisation if the MSP ID has not been configured", func() {
+			proxy.SetMSPID("")
+
+			err := readState("$implicit_org")
+			Expect(err).To(MatchError("ReadState failed for collection $implicit_org: The peer MSP ID has not been configured"))
+			Expect(stub.GetPrivateDataCallCount()).To(Equal(0))
+		})
+
+		It("should fail with an invalid implicit collection name", func() {
+			err := readState("$implicit_org:")
+			Expect(err).To(MatchError("ReadState failed for collection $implicit_org:: Invalid implicit collection $implicit_org:"))
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_INVALID_REQUEST))
+		})
+	})
+})
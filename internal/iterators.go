@@ -0,0 +1,178 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultIteratorChunkSize is the number of states returned by an
+// IteratorNext host call if the guest does not specify a maximum
+const DefaultIteratorChunkSize = 100
+
+// MaxIteratorChunkSize is the largest number of states returned by an
+// IteratorNext host call, so that the memory used by each call is bounded
+const MaxIteratorChunkSize = 1000
+
+func (proxy *FabricProxy) openStatesIterator(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &contract.GetStatesRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	collectionName := request.GetCollection().GetName()
+	logger.Debug("OpenStatesIterator", contextFields(context)...)
+	traceRequest(ctx, context, "", request.GetCollection())
+
+	stub, err := proxy.ledger(context)
+	if err != nil {
+		return nil, wrapOperationError("OpenStatesIterator", collectionName, "", err)
+	}
+
+	var iterator shim.StateQueryIteratorInterface
+	var iteratorID string
+	switch qt := request.Query.(type) {
+	case *contract.GetStatesRequest_ByKeyRange:
+		keyRangeQuery := request.GetByKeyRange()
+		iterator, err = stub.GetPrivateDataByRange(collectionName, keyRangeQuery.GetStartKey(), keyRangeQuery.GetEndKey())
+		if err != nil {
+			return nil, wrapOperationError("OpenStatesIterator (ByKeyRange)", collectionName, "", err)
+		}
+	default:
+		return nil, newOperationError(hostapi.ErrorCode_NOT_SUPPORTED, "OpenStatesIterator", collectionName, "", "unsupported query type %T", qt)
+	}
+
+	resources, err := proxy.contextStore.Resources(context)
+	if err == nil {
+		iteratorID, err = resources.Acquire(ResourceIterator, iterator)
+	}
+	if err != nil {
+		iterator.Close()
+		return nil, newOperationError(hostapi.ErrorCode_INVALID_CONTEXT, "OpenStatesIterator", collectionName, "", "%s", err.Error())
+	}
+
+	response := &hostapi.OpenStatesIteratorResponse{
+		IteratorId: iteratorID,
+	}
+
+	return proto.Marshal(response)
+}
+
+// iterator returns the query iterator for a handle in a request
+func (proxy *FabricProxy) iterator(operation string, context *contract.TransactionContext, iteratorID string) (shim.StateQueryIteratorInterface, error) {
+	resources, err := proxy.contextStore.Resources(context)
+	if err != nil {
+		return nil, newOperationError(hostapi.ErrorCode_INVALID_CONTEXT, operation, "", "", "%s", err.Error())
+	}
+
+	iterator, ok := resources.Lookup(ResourceIterator, iteratorID)
+	if !ok {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, operation, "", "", "Iterator %s does not exist", iteratorID)
+	}
+
+	return iterator.(shim.StateQueryIteratorInterface), nil
+}
+
+func (proxy *FabricProxy) iteratorNext(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.IteratorNextRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	iteratorID := request.GetIteratorId()
+	logger.Debug("IteratorNext", append(contextFields(context), zap.String("iterator", iteratorID))...)
+	traceRequest(ctx, context, "", nil)
+
+	iterator, err := proxy.iterator("IteratorNext", context, iteratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	maxStates := int(request.GetMaxStates())
+	if maxStates == 0 {
+		maxStates = DefaultIteratorChunkSize
+	} else if maxStates > MaxIteratorChunkSize {
+		maxStates = MaxIteratorChunkSize
+	}
+
+	response := &hostapi.IteratorNextResponse{}
+	for len(response.States) < maxStates && iterator.HasNext() {
+		queryResponse, err := iterator.Next()
+		if err != nil {
+			return nil, wrapOperationError("IteratorNext", "", "", err)
+		}
+
+		response.States = append(response.States, &contract.State{
+			Key:   queryResponse.Key,
+			Value: queryResponse.Value,
+		})
+	}
+	response.HasNext = iterator.HasNext()
+
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) iteratorHasNext(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.IteratorHasNextRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	iteratorID := request.GetIteratorId()
+	logger.Debug("IteratorHasNext", append(contextFields(context), zap.String("iterator", iteratorID))...)
+	traceRequest(ctx, context, "", nil)
+
+	iterator, err := proxy.iterator("IteratorHasNext", context, iteratorID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &hostapi.IteratorHasNextResponse{
+		HasNext: iterator.HasNext(),
+	}
+
+	return proto.Marshal(response)
+}
+
+func (proxy *FabricProxy) closeIterator(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.CloseIteratorRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	iteratorID := request.GetIteratorId()
+	logger.Debug("CloseIterator", append(contextFields(context), zap.String("iterator", iteratorID))...)
+	traceRequest(ctx, context, "", nil)
+
+	resources, err := proxy.contextStore.Resources(context)
+	if err != nil {
+		return nil, newOperationError(hostapi.ErrorCode_INVALID_CONTEXT, "CloseIterator", "", "", "%s", err.Error())
+	}
+
+	iterator, ok := resources.Release(ResourceIterator, iteratorID)
+	if !ok {
+		return nil, newOperationError(hostapi.ErrorCode_NOT_FOUND, "CloseIterator", "", "", "Iterator %s does not exist", iteratorID)
+	}
+
+	err = iterator.Close()
+	if err != nil {
+		return nil, wrapOperationError("CloseIterator", "", "", err)
+	}
+
+	return proto.Marshal(&hostapi.CloseIteratorResponse{})
+}
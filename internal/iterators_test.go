@@ -0,0 +1,257 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ = Describe("Iterators", func() {
+	var (
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		stub         *fakes.ChaincodeStubInterface
+		sqi          *fakes.StateQueryIteratorInterface
+		txContext    *contract.TransactionContext
+		ctx          context.Context
+	)
+
+	// iterate makes the fake iterator return count states
+	iterate := func(count int) {
+		next := 0
+		sqi.HasNextStub = func() bool {
+			return next < count
+		}
+		sqi.NextStub = func() (*queryresult.KV, error) {
+			next++
+			return &queryresult.KV{Key: fmt.Sprintf("%03d", next), Value: []byte("bond")}, nil
+		}
+	}
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		ctx = context.Background()
+
+		sqi = &fakes.StateQueryIteratorInterface{}
+		stub = &fakes.ChaincodeStubInterface{}
+		stub.GetStateByRangeReturns(sqi, nil)
+		stub.GetPrivateDataByRangeReturns(sqi, nil)
+		contextStore.Put("channel1", "txn1", stub)
+		txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+	})
+
+	openStatesIterator := func(collection string) (string, error) {
+		request := &contract.GetStatesRequest{
+			Context:    txContext,
+			Collection: &contract.Collection{Name: collection},
+			Query: &contract.GetStatesRequest_ByKeyRange{
+				ByKeyRange: &contract.KeyRangeQuery{StartKey: "001", EndKey: "999"},
+			},
+		}
+		payload, _ := proto.Marshal(request)
+		result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "OpenStatesIterator", payload)
+		if err != nil {
+			return "", err
+		}
+
+		response := &hostapi.OpenStatesIteratorResponse{}
+		Expect(proto.Unmarshal(result, response)).To(Succeed())
+		return response.GetIteratorId(), nil
+	}
+
+	iteratorNext := func(iteratorID string, maxStates uint32) (*hostapi.IteratorNextResponse, error) {
+		payload, _ := proto.Marshal(&hostapi.IteratorNextRequest{Context: txContext, IteratorId: iteratorID, MaxStates: maxStates})
+		result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "IteratorNext", payload)
+		if err != nil {
+			return nil, err
+		}
+
+		response := &hostapi.IteratorNextResponse{}
+		Expect(proto.Unmarshal(result, response)).To(Succeed())
+		return response, nil
+	}
+
+	closeIterator := func(iteratorID string) error {
+		payload, _ := proto.Marshal(&hostapi.CloseIteratorRequest{Context: txContext, IteratorId: iteratorID})
+		_, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "CloseIterator", payload)
+		return err
+	}
+
+	Describe("OpenStatesIterator", func() {
+		It("should open a world state range query", func() {
+			iteratorID, err := openStatesIterator("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(iteratorID).NotTo(BeEmpty())
+
+			Expect(stub.GetStateByRangeCallCount()).To(Equal(1))
+			startKey, endKey := stub.GetStateByRangeArgsForCall(0)
+			Expect(startKey).To(Equal("001"))
+			Expect(endKey).To(Equal("999"))
+			Expect(sqi.HasNextCallCount()).To(Equal(0), "Should not read any results")
+		})
+
+		It("should open a private data range query", func() {
+			_, err := openStatesIterator("$implicit_org:Org2MSP")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stub.GetPrivateDataByRangeCallCount()).To(Equal(1))
+			collection, _, _ := stub.GetPrivateDataByRangeArgsForCall(0)
+			Expect(collection).To(Equal("_implicit_org_Org2MSP"))
+		})
+
+		It("should return a different handle for each iterator", func() {
+			first, err := openStatesIterator("")
+			Expect(err).NotTo(HaveOccurred())
+			second, err := openStatesIterator("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).NotTo(Equal(second))
+		})
+
+		It("should fail if the query fails", func() {
+			stub.GetStateByRangeReturns(nil, errors.New("Ledger error"))
+
+			_, err := openStatesIterator("")
+			Expect(err).To(MatchError("OpenStatesIterator (ByKeyRange) failed: Ledger error"))
+		})
+	})
+
+	Describe("IteratorNext", func() {
+		It("should return results in chunks", func() {
+			iterate(5)
+			iteratorID, _ := openStatesIterator("")
+
+			response, err := iteratorNext(iteratorID, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetStates()).To(HaveLen(2))
+			Expect(response.GetStates()[0].GetKey()).To(Equal("001"))
+			Expect(response.GetStates()[1].GetKey()).To(Equal("002"))
+			Expect(response.GetHasNext()).To(BeTrue())
+
+			response, err = iteratorNext(iteratorID, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetStates()).To(HaveLen(2))
+			Expect(response.GetStates()[0].GetKey()).To(Equal("003"))
+			Expect(response.GetHasNext()).To(BeTrue())
+
+			response, err = iteratorNext(iteratorID, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetStates()).To(HaveLen(1))
+			Expect(response.GetStates()[0].GetKey()).To(Equal("005"))
+			Expect(response.GetHasNext()).To(BeFalse())
+		})
+
+		It("should use the default chunk size", func() {
+			iterate(internal.DefaultIteratorChunkSize + 1)
+			iteratorID, _ := openStatesIterator("")
+
+			response, err := iteratorNext(iteratorID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetStates()).To(HaveLen(internal.DefaultIteratorChunkSize))
+			Expect(response.GetHasNext()).To(BeTrue())
+		})
+
+		It("should limit the chunk size", func() {
+			iterate(internal.MaxIteratorChunkSize + 1)
+			iteratorID, _ := openStatesIterator("")
+
+			response, err := iteratorNext(iteratorID, internal.MaxIteratorChunkSize*2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.GetStates()).To(HaveLen(internal.MaxIteratorChunkSize))
+		})
+
+		It("should fail if reading a result fails", func() {
+			sqi.HasNextReturns(true)
+			sqi.NextReturns(nil, errors.New("Ledger error"))
+			iteratorID, _ := openStatesIterator("")
+
+			_, err := iteratorNext(iteratorID, 0)
+			Expect(err).To(MatchError("IteratorNext failed: Ledger error"))
+		})
+
+		It("should fail for an unknown iterator", func() {
+			_, err := iteratorNext("42", 0)
+			Expect(err).To(MatchError("IteratorNext failed: Iterator 42 does not exist"))
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+		})
+
+		It("should fail for an iterator from another transaction", func() {
+			iteratorID, _ := openStatesIterator("")
+			contextStore.Put("channel1", "txn2", stub)
+			txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn2"}
+
+			_, err := iteratorNext(iteratorID, 0)
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+		})
+
+		It("should fail for an unknown transaction context", func() {
+			txContext = &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn9"}
+
+			_, err := iteratorNext("1", 0)
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_INVALID_CONTEXT))
+		})
+	})
+
+	Describe("IteratorHasNext", func() {
+		It("should report whether the iterator has more results", func() {
+			iterate(1)
+			iteratorID, _ := openStatesIterator("")
+
+			payload, _ := proto.Marshal(&hostapi.IteratorHasNextRequest{Context: txContext, IteratorId: iteratorID})
+			result, err := proxy.FabricCall(ctx, "wapc", "LedgerService", "IteratorHasNext", payload)
+			Expect(err).NotTo(HaveOccurred())
+
+			response := &hostapi.IteratorHasNextResponse{}
+			Expect(proto.Unmarshal(result, response)).To(Succeed())
+			Expect(response.GetHasNext()).To(BeTrue())
+		})
+	})
+
+	Describe("CloseIterator", func() {
+		It("should close the iterator", func() {
+			iteratorID, _ := openStatesIterator("")
+
+			Expect(closeIterator(iteratorID)).To(Succeed())
+			Expect(sqi.CloseCallCount()).To(Equal(1))
+
+			_, err := iteratorNext(iteratorID, 0)
+			Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_NOT_FOUND))
+		})
+
+		It("should fail for an unknown iterator", func() {
+			err := closeIterator("42")
+			Expect(err).To(MatchError("CloseIterator failed: Iterator 42 does not exist"))
+		})
+	})
+
+	Describe("Transaction end", func() {
+		It("should close iterators left open by the guest", func() {
+			_, err := openStatesIterator("")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(contextStore.Remove("channel1", "txn1")).To(Succeed())
+			Expect(sqi.CloseCallCount()).To(Equal(1))
+		})
+
+		It("should not close iterators which were already closed", func() {
+			iteratorID, _ := openStatesIterator("")
+			Expect(closeIterator(iteratorID)).To(Succeed())
+
+			Expect(contextStore.Remove("channel1", "txn1")).To(Succeed())
+			Expect(sqi.CloseCallCount()).To(Equal(1))
+		})
+	})
+})
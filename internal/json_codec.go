@@ -0,0 +1,157 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// CapabilityJSON is the capability for host calls to use the json binding,
+// which takes and returns the JSON encoding of the usual protobuf messages, for
+// guests without a protobuf library
+const CapabilityJSON = "JSON"
+
+const jsonBinding = "json"
+
+// hostCallMessages creates the request and response messages for a host call
+type hostCallMessages struct {
+	request  func() proto.Message
+	response func() proto.Message
+}
+
+// jsonHostCalls lists the host calls available with the json binding, by
+// namespace and operation. Every host call implemented by the host must be
+// listed, since the table also bounds the host call metric labels
+var jsonHostCalls = map[string]hostCallMessages{
+	"LedgerService.CreateState": {
+		request:  func() proto.Message { return &contract.CreateStateRequest{} },
+		response: func() proto.Message { return &contract.CreateStateResponse{} },
+	},
+	"LedgerService.ReadState": {
+		request:  func() proto.Message { return &contract.ReadStateRequest{} },
+		response: func() proto.Message { return &contract.ReadStateResponse{} },
+	},
+	"LedgerService.ReadOptionalState": {
+		request:  func() proto.Message { return &contract.ReadStateRequest{} },
+		response: func() proto.Message { return &hostapi.ReadOptionalStateResponse{} },
+	},
+	"LedgerService.ExistsState": {
+		request:  func() proto.Message { return &contract.ExistsStateRequest{} },
+		response: func() proto.Message { return &contract.ExistsStateResponse{} },
+	},
+	"LedgerService.UpdateState": {
+		request:  func() proto.Message { return &contract.UpdateStateRequest{} },
+		response: func() proto.Message { return &contract.UpdateStateResponse{} },
+	},
+	"LedgerService.PutState": {
+		request:  func() proto.Message { return &hostapi.PutStateRequest{} },
+		response: func() proto.Message { return &hostapi.PutStateResponse{} },
+	},
+	"LedgerService.DeleteState": {
+		request:  func() proto.Message { return &contract.DeleteStateRequest{} },
+		response: func() proto.Message { return &contract.DeleteStateResponse{} },
+	},
+	"LedgerService.PurgeState": {
+		request:  func() proto.Message { return &hostapi.PurgeStateRequest{} },
+		response: func() proto.Message { return &hostapi.PurgeStateResponse{} },
+	},
+	"LedgerService.GetTransient": {
+		request:  func() proto.Message { return &hostapi.GetTransientRequest{} },
+		response: func() proto.Message { return &hostapi.GetTransientResponse{} },
+	},
+	"LedgerService.GetMSPID": {
+		request:  func() proto.Message { return &hostapi.GetMSPIDRequest{} },
+		response: func() proto.Message { return &hostapi.GetMSPIDResponse{} },
+	},
+	"LedgerService.OpenStatesIterator": {
+		request:  func() proto.Message { return &contract.GetStatesRequest{} },
+		response: func() proto.Message { return &hostapi.OpenStatesIteratorResponse{} },
+	},
+	"LedgerService.IteratorNext": {
+		request:  func() proto.Message { return &hostapi.IteratorNextRequest{} },
+		response: func() proto.Message { return &hostapi.IteratorNextResponse{} },
+	},
+	"LedgerService.IteratorHasNext": {
+		request:  func() proto.Message { return &hostapi.IteratorHasNextRequest{} },
+		response: func() proto.Message { return &hostapi.IteratorHasNextResponse{} },
+	},
+	"LedgerService.CloseIterator": {
+		request:  func() proto.Message { return &hostapi.CloseIteratorRequest{} },
+		response: func() proto.Message { return &hostapi.CloseIteratorResponse{} },
+	},
+	"LedgerService.GetCollectionConfig": {
+		request:  func() proto.Message { return &hostapi.GetCollectionConfigRequest{} },
+		response: func() proto.Message { return &hostapi.GetCollectionConfigResponse{} },
+	},
+	"LedgerService.IsCollectionMember": {
+		request:  func() proto.Message { return &hostapi.IsCollectionMemberRequest{} },
+		response: func() proto.Message { return &hostapi.IsCollectionMemberResponse{} },
+	},
+	"LedgerService.GetHash": {
+		request:  func() proto.Message { return &contract.GetHashRequest{} },
+		response: func() proto.Message { return &contract.GetHashResponse{} },
+	},
+	"LedgerService.GetStates": {
+		request:  func() proto.Message { return &contract.GetStatesRequest{} },
+		response: func() proto.Message { return &contract.GetStatesResponse{} },
+	},
+	"LedgerService.GetMultipleStates": {
+		request:  func() proto.Message { return &hostapi.GetMultipleStatesRequest{} },
+		response: func() proto.Message { return &hostapi.GetMultipleStatesResponse{} },
+	},
+	"LedgerService.BatchRequest": {
+		request:  func() proto.Message { return &hostapi.BatchRequest{} },
+		response: func() proto.Message { return &hostapi.BatchResponse{} },
+	},
+	"LedgerService.GetWriteSet": {
+		request:  func() proto.Message { return &hostapi.GetWriteSetRequest{} },
+		response: func() proto.Message { return &hostapi.GetWriteSetResponse{} },
+	},
+	"LogService.Log": {
+		request: func() proto.Message { return &hostapi.LogRequest{} },
+	},
+}
+
+// jsonCall converts a JSON payload to protobuf, calls the usual handler for
+// the operation, and converts the result back to JSON, so that operations
+// behave exactly the same with either encoding
+func (proxy *FabricProxy) jsonCall(ctx context.Context, namespace, operation string, payload []byte) ([]byte, error) {
+	messages, ok := jsonHostCalls[namespace+"."+operation]
+	if !ok {
+		return nil, newHostCallError(hostapi.ErrorCode_NOT_SUPPORTED, "Operation not supported: %s %s %s", jsonBinding, namespace, operation)
+	}
+
+	request := messages.request()
+	err := protojson.Unmarshal(payload, request)
+	if err != nil {
+		return nil, newHostCallError(hostapi.ErrorCode_INVALID_REQUEST, "Invalid JSON payload for %s %s: %s", namespace, operation, err.Error())
+	}
+
+	requestPayload, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := proxy.call(ctx, "wapc", namespace, operation, requestPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	if messages.response == nil {
+		return nil, nil
+	}
+
+	response := messages.response()
+	err = proto.Unmarshal(result, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(response)
+}
@@ -0,0 +1,93 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+)
+
+var _ = Describe("JSON host calls", func() {
+	var (
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		stub         *fakes.ChaincodeStubInterface
+		ctx          context.Context
+	)
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityJSON))
+		ctx = context.Background()
+
+		stub = &fakes.ChaincodeStubInterface{}
+		contextStore.Put("channel1", "txn1", stub)
+	})
+
+	It("should create a state", func() {
+		payload := `{"context": {"channelId": "channel1", "transactionId": "txn1"}, "state": {"key": "007", "value": "Ym9uZA=="}}`
+
+		result, err := proxy.FabricCall(ctx, "json", "LedgerService", "CreateState", []byte(payload))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchJSON("{}"))
+
+		Expect(stub.PutStateCallCount()).To(Equal(1), "Should call PutState once")
+		key, value := stub.PutStateArgsForCall(0)
+		Expect(key).To(Equal("007"))
+		Expect(value).To(Equal([]byte("bond")))
+	})
+
+	It("should read a state", func() {
+		stub.GetStateReturns([]byte("bond"), nil)
+		payload := `{"context": {"channel_id": "channel1", "transaction_id": "txn1"}, "stateKey": "007"}`
+
+		result, err := proxy.FabricCall(ctx, "json", "LedgerService", "ReadState", []byte(payload))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(MatchJSON(`{"state": {"key": "007", "value": "Ym9uZA=="}}`))
+	})
+
+	It("should return the same errors as protobuf host calls", func() {
+		payload := `{"context": {"channelId": "channel1", "transactionId": "txn1"}, "stateKey": "007"}`
+
+		_, err := proxy.FabricCall(ctx, "json", "LedgerService", "ReadState", []byte(payload))
+		Expect(err).To(MatchError("ReadState failed: State 007 does not exist"))
+	})
+
+	It("should execute a batch of operations", func() {
+		stub.GetStateReturns([]byte("bond"), nil)
+		payload := `{"items": [{"exists": {"context": {"channelId": "channel1", "transactionId": "txn1"}, "stateKey": "007"}}]}`
+
+		result, err := proxy.FabricCall(ctx, "json", "LedgerService", "BatchRequest", []byte(payload))
+		Expect(err).NotTo(HaveOccurred())
+
+		var response map[string]interface{}
+		Expect(json.Unmarshal(result, &response)).To(Succeed())
+		Expect(response).To(HaveKeyWithValue("results", ConsistOf(HaveKeyWithValue("exists", HaveKeyWithValue("exists", true)))))
+	})
+
+	It("should fail with an invalid payload", func() {
+		_, err := proxy.FabricCall(ctx, "json", "LedgerService", "ReadState", []byte(`{"stateKey": 7}`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("Invalid JSON payload for LedgerService ReadState"))
+	})
+
+	It("should fail with an unsupported operation", func() {
+		_, err := proxy.FabricCall(ctx, "json", "LedgerService", "MutateState", []byte("{}"))
+		Expect(err).To(MatchError("Operation not supported: json LedgerService MutateState"))
+	})
+
+	It("should not be supported without the capability", func() {
+		proxy.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion))
+
+		_, err := proxy.FabricCall(ctx, "json", "LedgerService", "ReadState", []byte("{}"))
+		Expect(err).To(MatchError("Operation not supported: json LedgerService ReadState"))
+	})
+})
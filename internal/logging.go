@@ -0,0 +1,89 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logger is used throughout the Wasm chaincode host and discards everything
+// until SetLogger is called
+var logger = zap.NewNop()
+
+// LogConfig is used to configure the host logger
+type LogConfig struct {
+	// Level is one of debug, info, warn or error
+	Level string `yaml:"level" env:"CHAINCODE_LOG_LEVEL" usage:"log level: debug, info, warn or error"`
+	// Format is either json or console
+	Format string `yaml:"format" env:"CHAINCODE_LOG_FORMAT" usage:"log format: json or console"`
+}
+
+// NewLogger returns a levelled, structured logger for the specified configuration
+func NewLogger(config LogConfig) (*zap.Logger, error) {
+	level := zap.NewAtomicLevel()
+	if config.Level != "" {
+		err := level.UnmarshalText([]byte(strings.ToLower(config.Level)))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid log level %s", config.Level)
+		}
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "time"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	zapConfig := zap.Config{
+		Level:            level,
+		Encoding:         "json",
+		EncoderConfig:    encoderConfig,
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+
+	switch strings.ToLower(config.Format) {
+	case "", "json":
+	case "console":
+		zapConfig.Encoding = "console"
+		zapConfig.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	default:
+		return nil, fmt.Errorf("Invalid log format %s", config.Format)
+	}
+
+	return zapConfig.Build()
+}
+
+// SetLogger sets the logger used by the Wasm chaincode host
+func SetLogger(l *zap.Logger) {
+	logger = l
+}
+
+// contextFields returns the logging fields which identify a transaction context
+func contextFields(context *contract.TransactionContext) []zap.Field {
+	return []zap.Field{
+		zap.String("channel", context.GetChannelId()),
+		zap.String("txid", context.GetTransactionId()),
+	}
+}
+
+type transactionContextKey struct{}
+
+// WithTransactionContext returns a copy of ctx which identifies the transaction
+// being run by the Wasm guest, so that the host can tag guest log messages
+// with it
+func WithTransactionContext(ctx context.Context, txContext *contract.TransactionContext) context.Context {
+	return context.WithValue(ctx, transactionContextKey{}, txContext)
+}
+
+// TransactionContextFrom returns the transaction being run by the Wasm guest,
+// or nil if ctx does not identify a transaction
+func TransactionContextFrom(ctx context.Context) *contract.TransactionContext {
+	txContext, _ := ctx.Value(transactionContextKey{}).(*contract.TransactionContext)
+	return txContext
+}
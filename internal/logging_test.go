@@ -0,0 +1,38 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+)
+
+var _ = Describe("Logging", func() {
+	Describe("NewLogger", func() {
+		It("should default to info level", func() {
+			logger, err := internal.NewLogger(internal.LogConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.Core().Enabled(-1)).To(BeFalse(), "Should not enable debug")
+			Expect(logger.Core().Enabled(0)).To(BeTrue(), "Should enable info")
+		})
+
+		It("should support the console format at debug level", func() {
+			logger, err := internal.NewLogger(internal.LogConfig{Level: "DEBUG", Format: "console"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.Core().Enabled(-1)).To(BeTrue(), "Should enable debug")
+		})
+
+		It("should fail with an invalid level", func() {
+			_, err := internal.NewLogger(internal.LogConfig{Level: "chatty"})
+			Expect(err).To(MatchError("Invalid log level chatty"))
+		})
+
+		It("should fail with an invalid format", func() {
+			_, err := internal.NewLogger(internal.LogConfig{Format: "xml"})
+			Expect(err).To(MatchError("Invalid log format xml"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "wasmcc"
+
+// MetricsConfig is used to configure the Prometheus metrics endpoint
+type MetricsConfig struct {
+	// Address is the host and port to serve metrics on, or empty to disable
+	// the metrics endpoint
+	Address string `yaml:"address" env:"CHAINCODE_METRICS_ADDRESS" usage:"host and port to serve Prometheus metrics on"`
+}
+
+// otherLabel is the label used for transaction functions which are not
+// listed in the package manifest, and for host calls which are not
+// implemented by the host, so that clients and guests cannot create any number
+// of metric series by using made up names
+const otherLabel = "other"
+
+// hostCallLabels returns the namespace and operation labels for a host call,
+// which are only the names used by the guest for host calls implemented by
+// the host. Every host call is listed in the json binding table
+func hostCallLabels(namespace string, operation string) (string, string) {
+	if _, ok := jsonHostCalls[namespace+"."+operation]; ok {
+		return namespace, operation
+	}
+
+	return otherLabel, otherLabel
+}
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	transactionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "transaction_duration_seconds",
+		Help:      "Duration of Wasm transactions by function and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"function", "outcome"})
+
+	fabricCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "fabric_call_duration_seconds",
+		Help:      "Duration of FabricCall host calls by namespace, operation and outcome.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"namespace", "operation", "outcome"})
+
+	poolWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "pool_wait_duration_seconds",
+		Help:      "Time spent waiting for a waPC instance from the pool.",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+	})
+
+	poolExhaustedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "pool_exhausted_total",
+		Help:      "Number of times a waPC instance was not available from the pool.",
+	})
+
+	activeContexts = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "active_contexts",
+		Help:      "Number of transaction contexts in the context store.",
+	})
+
+	guestTrapsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "guest_traps_total",
+		Help:      "Number of Wasm guest operations which trapped.",
+	})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		transactionDuration,
+		fabricCallDuration,
+		poolWaitDuration,
+		poolExhaustedTotal,
+		activeContexts,
+		guestTrapsTotal,
+	)
+}
+
+// MetricsHandler returns an HTTP handler for the Prometheus metrics endpoint
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "success"
+}
+
+// isGuestTrap returns true if the error from a waPC invocation was caused by
+// the Wasm guest trapping, rather than the guest returning an error
+func isGuestTrap(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "error invoking guest")
+}
+
+func observeDuration(observer prometheus.Observer, start time.Time) {
+	observer.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,130 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+)
+
+func scrapeMetrics() string {
+	recorder := httptest.NewRecorder()
+	internal.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	Expect(recorder.Code).To(Equal(200))
+
+	body, err := ioutil.ReadAll(recorder.Body)
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(body)
+}
+
+func activeContexts() int {
+	matches := regexp.MustCompile(`(?m)^wasmcc_active_contexts (\d+)$`).FindStringSubmatch(scrapeMetrics())
+	Expect(matches).To(HaveLen(2))
+
+	value, err := strconv.Atoi(matches[1])
+	Expect(err).NotTo(HaveOccurred())
+
+	return value
+}
+
+var _ = Describe("Metrics", func() {
+	It("should record transactions by function and outcome", func() {
+		wasmInvoker := &fakes.WasmGuestInvoker{}
+		wasmContract := internal.NewWasmContract(internal.NewContextStore(), wasmInvoker)
+		wasmContract.SetManifest(&internal.PackageManifest{Transactions: []string{"metricsTest"}})
+
+		stub := &fakes.ChaincodeStubInterface{}
+		stub.GetFunctionAndParametersReturns("metricsTest", nil)
+		stub.GetTxIDReturns("metricstxn1")
+		wasmInvoker.InvokeWasmOperationReturns(nil, errors.New("nope"))
+
+		wasmContract.Invoke(stub)
+
+		Expect(scrapeMetrics()).To(ContainSubstring(`wasmcc_transaction_duration_seconds_count{function="metricsTest",outcome="error"} 1`))
+	})
+
+	It("should record functions missing from the manifest as other", func() {
+		wasmInvoker := &fakes.WasmGuestInvoker{}
+		wasmContract := internal.NewWasmContract(internal.NewContextStore(), wasmInvoker)
+		wasmContract.SetManifest(&internal.PackageManifest{
+			Transactions:         []string{"metricsTest"},
+			ReadOnlyTransactions: []string{"metricsRead", "metricsQuery*"},
+		})
+		wasmInvoker.InvokeWasmOperationReturns(nil, errors.New("nope"))
+
+		for i, function := range []string{"metricsUnknown", "metricsQueryCars", "metricsRead"} {
+			stub := &fakes.ChaincodeStubInterface{}
+			stub.GetFunctionAndParametersReturns(function, nil)
+			stub.GetTxIDReturns("metricstxn-other" + strconv.Itoa(i))
+			wasmContract.Invoke(stub)
+		}
+
+		metrics := scrapeMetrics()
+		Expect(metrics).NotTo(ContainSubstring(`function="metricsUnknown"`))
+		Expect(metrics).NotTo(ContainSubstring(`function="metricsQueryCars"`), "Should not use read-only patterns as labels")
+		Expect(metrics).To(ContainSubstring(`wasmcc_transaction_duration_seconds_count{function="metricsRead",outcome="error"} 1`))
+		Expect(metrics).To(MatchRegexp(`wasmcc_transaction_duration_seconds_count{function="other",outcome="error"} [2-9]`))
+	})
+
+	It("should record every function as other without a manifest", func() {
+		wasmInvoker := &fakes.WasmGuestInvoker{}
+		wasmContract := internal.NewWasmContract(internal.NewContextStore(), wasmInvoker)
+
+		stub := &fakes.ChaincodeStubInterface{}
+		stub.GetFunctionAndParametersReturns("metricsNoManifest", nil)
+		stub.GetTxIDReturns("metricstxn-nomanifest")
+		wasmContract.Invoke(stub)
+
+		Expect(scrapeMetrics()).NotTo(ContainSubstring(`function="metricsNoManifest"`))
+	})
+
+	It("should record FabricCall operations by outcome", func() {
+		proxy := internal.NewFabricProxy(internal.NewContextStore())
+
+		request := &contract.ExistsStateRequest{
+			Context:  &contract.TransactionContext{ChannelId: "channel1", TransactionId: "metricstxn2"},
+			StateKey: "007",
+		}
+		payload, _ := proto.Marshal(request)
+		proxy.FabricCall(context.Background(), "wapc", "LedgerService", "ExistsState", payload)
+
+		Expect(scrapeMetrics()).To(MatchRegexp(`wasmcc_fabric_call_duration_seconds_count{namespace="LedgerService",operation="ExistsState",outcome="error"} [1-9]`))
+	})
+
+	It("should record host calls which are not implemented as other", func() {
+		proxy := internal.NewFabricProxy(internal.NewContextStore())
+
+		proxy.FabricCall(context.Background(), "wapc", "MetricsService", "MetricsState", nil)
+		proxy.FabricCall(context.Background(), "wapc", "LedgerService", "MetricsMutateState", nil)
+
+		metrics := scrapeMetrics()
+		Expect(metrics).NotTo(ContainSubstring(`namespace="MetricsService"`))
+		Expect(metrics).NotTo(ContainSubstring(`operation="MetricsMutateState"`))
+		Expect(metrics).To(MatchRegexp(`wasmcc_fabric_call_duration_seconds_count{namespace="other",operation="other",outcome="error"} [2-9]`))
+	})
+
+	It("should track active contexts", func() {
+		contextStore := internal.NewContextStore()
+		before := activeContexts()
+
+		contextStore.Put("channel1", "metricstxn3", &fakes.ChaincodeStubInterface{})
+		Expect(activeContexts()).To(Equal(before + 1))
+
+		contextStore.Remove("channel1", "metricstxn3")
+		Expect(activeContexts()).To(Equal(before))
+	})
+})
@@ -0,0 +1,144 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// ResourceIterator is the type of query iterators opened by the guest
+const ResourceIterator = "iterator"
+
+// ResourceWriteSet is the type of the read-your-own-writes overlay, which is
+// held by the host for the whole transaction
+const ResourceWriteSet = "writeSet"
+
+// ResourceLeak describes a resource which was still held when its
+// transaction ended
+type ResourceLeak struct {
+	Type string
+	ID   string
+	// Owned is true if the resource was held by the host rather than the
+	// guest, so it was not leaked by the guest
+	Owned bool
+	// Err is the error closing the resource, if any
+	Err error
+}
+
+type scopedResource struct {
+	resourceType string
+	resource     io.Closer
+	owned        bool
+}
+
+// ResourceScope keeps track of the resources held for a transaction, such as
+// query iterators opened by the guest and the write set overlay held by the
+// host, so that they are released when the transaction ends even if the guest
+// does not release them
+type ResourceScope struct {
+	sync.Mutex
+	resources map[string]*scopedResource
+	order     []string
+	nextID    int
+	closed    bool
+}
+
+// NewResourceScope returns a new, empty resource scope
+func NewResourceScope() *ResourceScope {
+	return &ResourceScope{
+		resources: make(map[string]*scopedResource),
+	}
+}
+
+// Acquire adds a resource held by the guest to the scope, and returns an
+// opaque handle for it
+func (scope *ResourceScope) Acquire(resourceType string, resource io.Closer) (string, error) {
+	return scope.add(&scopedResource{resourceType: resourceType, resource: resource})
+}
+
+// Hold adds a resource held by the host to the scope. The resource is closed
+// when the transaction ends, but is not reported as leaked by the guest
+func (scope *ResourceScope) Hold(resourceType string, resource io.Closer) (string, error) {
+	return scope.add(&scopedResource{resourceType: resourceType, resource: resource, owned: true})
+}
+
+func (scope *ResourceScope) add(r *scopedResource) (string, error) {
+	scope.Lock()
+	defer scope.Unlock()
+
+	if scope.closed {
+		return "", fmt.Errorf("Unable to acquire %s after the transaction has ended", r.resourceType)
+	}
+
+	scope.nextID++
+	id := strconv.Itoa(scope.nextID)
+	scope.resources[id] = r
+	scope.order = append(scope.order, id)
+
+	return id, nil
+}
+
+// Lookup returns the resource with the specified handle, if it has the
+// specified type
+func (scope *ResourceScope) Lookup(resourceType string, id string) (io.Closer, bool) {
+	scope.Lock()
+	defer scope.Unlock()
+
+	r, ok := scope.resources[id]
+	if !ok || r.resourceType != resourceType {
+		return nil, false
+	}
+
+	return r.resource, true
+}
+
+// Release removes the resource with the specified handle from the scope, if
+// it has the specified type, and returns it so that it can be closed
+func (scope *ResourceScope) Release(resourceType string, id string) (io.Closer, bool) {
+	scope.Lock()
+	defer scope.Unlock()
+
+	r, ok := scope.resources[id]
+	if !ok || r.resourceType != resourceType {
+		return nil, false
+	}
+
+	delete(scope.resources, id)
+	for i, orderID := range scope.order {
+		if orderID == id {
+			scope.order = append(scope.order[:i], scope.order[i+1:]...)
+			break
+		}
+	}
+
+	return r.resource, true
+}
+
+// Teardown closes any resources which are still held, most recently acquired
+// first, and returns them. No more resources can be acquired afterwards
+func (scope *ResourceScope) Teardown() []ResourceLeak {
+	scope.Lock()
+	scope.closed = true
+	order := scope.order
+	resources := scope.resources
+	scope.order = nil
+	scope.resources = make(map[string]*scopedResource)
+	scope.Unlock()
+
+	var leaks []ResourceLeak
+	for i := len(order) - 1; i >= 0; i-- {
+		r := resources[order[i]]
+		leaks = append(leaks, ResourceLeak{
+			Type:  r.resourceType,
+			ID:    order[i],
+			Owned: r.owned,
+			Err:   r.resource.Close(),
+		})
+	}
+
+	return leaks
+}
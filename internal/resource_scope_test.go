@@ -0,0 +1,179 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// closeRecorder records the order in which resources are closed
+type closeRecorder struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (r *closeRecorder) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return r.err
+}
+
+var _ = Describe("ResourceScope", func() {
+	var (
+		scope  *internal.ResourceScope
+		closed []string
+	)
+
+	BeforeEach(func() {
+		scope = internal.NewResourceScope()
+		closed = nil
+	})
+
+	resource := func(name string) *closeRecorder {
+		return &closeRecorder{name: name, closed: &closed}
+	}
+
+	It("should look up acquired resources by type and handle", func() {
+		first := resource("first")
+		id, err := scope.Acquire("iterator", first)
+		Expect(err).NotTo(HaveOccurred())
+
+		r, ok := scope.Lookup("iterator", id)
+		Expect(ok).To(BeTrue())
+		Expect(r).To(BeIdenticalTo(first))
+
+		_, ok = scope.Lookup("event", id)
+		Expect(ok).To(BeFalse(), "Should not return a resource of a different type")
+	})
+
+	It("should not close released resources on teardown", func() {
+		id, _ := scope.Acquire("iterator", resource("first"))
+
+		_, ok := scope.Release("iterator", id)
+		Expect(ok).To(BeTrue())
+
+		_, ok = scope.Release("iterator", id)
+		Expect(ok).To(BeFalse(), "Should only release a resource once")
+
+		Expect(scope.Teardown()).To(BeEmpty())
+		Expect(closed).To(BeEmpty())
+	})
+
+	It("should close leaked resources in reverse order on teardown", func() {
+		scope.Acquire("iterator", resource("first"))
+		id, _ := scope.Acquire("iterator", resource("second"))
+		scope.Acquire("iterator", resource("third"))
+		scope.Release("iterator", id)
+
+		leaks := scope.Teardown()
+		Expect(closed).To(Equal([]string{"third", "first"}))
+		Expect(leaks).To(HaveLen(2))
+		Expect(leaks[0].Type).To(Equal("iterator"))
+		Expect(leaks[0].ID).To(Equal("3"))
+		Expect(leaks[1].ID).To(Equal("1"))
+	})
+
+	It("should report errors closing leaked resources", func() {
+		failing := resource("failing")
+		failing.err = errors.New("Close failed")
+		scope.Acquire("iterator", failing)
+
+		leaks := scope.Teardown()
+		Expect(leaks).To(HaveLen(1))
+		Expect(leaks[0].Err).To(MatchError("Close failed"))
+	})
+
+	It("should close resources held by the host on teardown", func() {
+		scope.Hold("writeSet", resource("writeSet"))
+		scope.Acquire("iterator", resource("iterator"))
+
+		leaks := scope.Teardown()
+		Expect(closed).To(Equal([]string{"iterator", "writeSet"}))
+		Expect(leaks).To(HaveLen(2))
+		Expect(leaks[0].Owned).To(BeFalse())
+		Expect(leaks[1].Type).To(Equal("writeSet"))
+		Expect(leaks[1].Owned).To(BeTrue())
+	})
+
+	It("should not acquire resources after teardown", func() {
+		scope.Teardown()
+
+		_, err := scope.Acquire("iterator", resource("late"))
+		Expect(err).To(MatchError("Unable to acquire iterator after the transaction has ended"))
+	})
+
+	Describe("Transaction end", func() {
+		var logs *observer.ObservedLogs
+
+		BeforeEach(func() {
+			var core zapcore.Core
+			core, logs = observer.New(zapcore.DebugLevel)
+			internal.SetLogger(zap.New(core))
+		})
+
+		AfterEach(func() {
+			internal.SetLogger(zap.NewNop())
+		})
+
+		It("should warn about resources the guest did not release", func() {
+			contextStore := internal.NewContextStore()
+			Expect(contextStore.Put("channel1", "txn1", &fakes.ChaincodeStubInterface{})).To(Succeed())
+
+			resources, err := contextStore.Resources(&contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"})
+			Expect(err).NotTo(HaveOccurred())
+			resources.Acquire(internal.ResourceIterator, resource("iterator"))
+
+			Expect(contextStore.Remove("channel1", "txn1")).To(Succeed())
+			Expect(closed).To(Equal([]string{"iterator"}))
+
+			leaks := logs.FilterMessage("Guest did not release resource")
+			Expect(leaks.Len()).To(Equal(1))
+			Expect(leaks.All()[0].Level).To(Equal(zapcore.WarnLevel))
+			Expect(leaks.All()[0].ContextMap()).To(Equal(map[string]interface{}{
+				"channel":      "channel1",
+				"txid":         "txn1",
+				"resourceType": "iterator",
+				"resource":     "1",
+			}))
+		})
+
+		It("should release the write set without warning", func() {
+			contextStore := internal.NewContextStore()
+			Expect(contextStore.Put("channel1", "txn1", &fakes.ChaincodeStubInterface{})).To(Succeed())
+
+			writes, err := contextStore.WriteSet(&contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"})
+			Expect(err).NotTo(HaveOccurred())
+			writes.Put("", "007", []byte("bond"))
+
+			Expect(contextStore.Remove("channel1", "txn1")).To(Succeed())
+			Expect(writes.Entries()).To(BeEmpty(), "Should discard the pending writes")
+			Expect(logs.FilterMessage("Guest did not release resource").Len()).To(Equal(0))
+
+			writes.Put("", "008", []byte("moneypenny"))
+			Expect(writes.Entries()).To(BeEmpty(), "Should not record writes after the transaction has ended")
+		})
+
+		It("should not warn when the guest released its resources", func() {
+			contextStore := internal.NewContextStore()
+			Expect(contextStore.Put("channel1", "txn1", &fakes.ChaincodeStubInterface{})).To(Succeed())
+
+			resources, _ := contextStore.Resources(&contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"})
+			id, _ := resources.Acquire(internal.ResourceIterator, resource("iterator"))
+			resources.Release(internal.ResourceIterator, id)
+
+			Expect(contextStore.Remove("channel1", "txn1")).To(Succeed())
+			Expect(logs.FilterMessage("Guest did not release resource").Len()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,97 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sort"
+	"strings"
+
+	// Register the hash algorithms used for world state hashes
+	_ "crypto/sha512"
+)
+
+// DefaultHashAlgorithm is the algorithm used for world state hashes if none is
+// configured
+const DefaultHashAlgorithm = "sha256"
+
+var hashAlgorithms = map[string]crypto.Hash{
+	"sha256": crypto.SHA256,
+	"sha384": crypto.SHA384,
+	"sha512": crypto.SHA512,
+}
+
+// HashConfig is used to configure the hashes returned by GetHash for world
+// state keys
+type HashConfig struct {
+	Algorithm string `yaml:"algorithm" env:"CHAINCODE_HASH_ALGORITHM" usage:"hash algorithm for world state GetHash calls: sha256, sha384 or sha512"`
+}
+
+// StateHasher computes the hashes of world state values. Unlike private data
+// hashes, which are stored on the ledger by the peer, world state hashes are
+// computed by the host from the current value of the state
+type StateHasher struct {
+	algorithm string
+	newHash   func() hash.Hash
+}
+
+// NewStateHasher returns a new hasher using the configured algorithm
+func NewStateHasher(config HashConfig) (*StateHasher, error) {
+	algorithm := strings.ToLower(config.Algorithm)
+	if algorithm == "" {
+		algorithm = DefaultHashAlgorithm
+	}
+
+	hashAlgorithm, ok := hashAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("Invalid hash algorithm %s", config.Algorithm)
+	}
+
+	hasher := &StateHasher{
+		algorithm: algorithm,
+		newHash:   hashAlgorithm.New,
+	}
+
+	return hasher, nil
+}
+
+// Algorithm returns the name of the hash algorithm
+func (hasher *StateHasher) Algorithm() string {
+	if hasher == nil {
+		return DefaultHashAlgorithm
+	}
+
+	return hasher.algorithm
+}
+
+// Hash returns the hash of a state value. A nil hasher uses the default
+// algorithm
+func (hasher *StateHasher) Hash(value []byte) []byte {
+	if hasher == nil {
+		sum := sha256.Sum256(value)
+		return sum[:]
+	}
+
+	h := hasher.newHash()
+	h.Write(value)
+	return h.Sum(nil)
+}
+
+func validHashAlgorithm(algorithm string) bool {
+	_, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	return algorithm == "" || ok
+}
+
+func hashAlgorithmNames() string {
+	names := make([]string, 0, len(hashAlgorithms))
+	for name := range hashAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
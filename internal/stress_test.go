@@ -0,0 +1,262 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// The stress tests run many transactions through the same ContextStore at
+// once, with a fake guest which makes host calls back into the FabricProxy, so
+// that the race detector can check the shared state
+var _ = Describe("Concurrent transactions", func() {
+	const (
+		channels     = 4
+		transactions = 200
+	)
+
+	var (
+		contextStore *internal.ContextStore
+		proxy        *internal.FabricProxy
+		wasmContract *internal.WasmContract
+		wasmInvoker  *fakes.WasmGuestInvoker
+
+		// replayEntered and replayRelease are used to hold the replay
+		// transaction in the guest
+		replayEntered chan struct{}
+		replayRelease chan struct{}
+	)
+
+	hostCall := func(operation string, request proto.Message, response proto.Message) error {
+		payload, err := proto.Marshal(request)
+		if err != nil {
+			return err
+		}
+
+		result, err := proxy.FabricCall(context.Background(), "wapc", "LedgerService", operation, payload)
+		if err != nil {
+			return err
+		}
+
+		return proto.Unmarshal(result, response)
+	}
+
+	// guest behaves like a Wasm guest for each transaction function
+	guest := func(ctx context.Context, operation string, args []byte) ([]byte, error) {
+		request := &contract.InvokeTransactionRequest{}
+		err := proto.Unmarshal(args, request)
+		if err != nil {
+			return nil, err
+		}
+
+		txContext := request.GetContext()
+		value := []byte(txContext.GetChannelId() + "/" + txContext.GetTransactionId())
+
+		var payload []byte
+		switch request.GetTransactionName() {
+		case "create":
+			state := &contract.State{Key: txContext.GetTransactionId(), Value: value}
+			err = hostCall("CreateState", &contract.CreateStateRequest{Context: txContext, State: state}, &contract.CreateStateResponse{})
+			if err != nil {
+				return nil, err
+			}
+
+			readResponse := &contract.ReadStateResponse{}
+			err = hostCall("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: state.Key}, readResponse)
+			if err != nil {
+				return nil, err
+			}
+
+			writeSet := &hostapi.GetWriteSetResponse{}
+			err = hostCall("GetWriteSet", &hostapi.GetWriteSetRequest{Context: txContext}, writeSet)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(writeSet.GetEntries()) != 1 {
+				return nil, fmt.Errorf("Expected 1 write set entry but found %d", len(writeSet.GetEntries()))
+			}
+
+			payload = readResponse.GetState().GetValue()
+		case "scan":
+			query := &contract.GetStatesRequest_ByKeyRange{ByKeyRange: &contract.KeyRangeQuery{}}
+			iteratorResponse := &hostapi.OpenStatesIteratorResponse{}
+			err = hostCall("OpenStatesIterator", &contract.GetStatesRequest{Context: txContext, Query: query}, iteratorResponse)
+			if err != nil {
+				return nil, err
+			}
+
+			// The iterator is deliberately left open for the host to close
+			payload = value
+		case "fail":
+			return nil, fmt.Errorf("Guest failed %s", value)
+		case "replay":
+			replayEntered <- struct{}{}
+			<-replayRelease
+			payload = value
+		default:
+			return nil, errors.New("Unknown transaction")
+		}
+
+		response, _ := proto.Marshal(&contract.InvokeTransactionResponse{Payload: payload})
+		return response, nil
+	}
+
+	newStub := func(channelID string, txID string, function string) *fakes.ChaincodeStubInterface {
+		stub := &fakes.ChaincodeStubInterface{}
+		stub.GetChannelIDReturns(channelID)
+		stub.GetTxIDReturns(txID)
+		stub.GetFunctionAndParametersReturns(function, nil)
+		return stub
+	}
+
+	// invokeAll invokes the function once per stub, all at the same time
+	invokeAll := func(stubs []*fakes.ChaincodeStubInterface) []pb.Response {
+		responses := make([]pb.Response, len(stubs))
+		start := make(chan struct{})
+
+		var wg sync.WaitGroup
+		for i, stub := range stubs {
+			wg.Add(1)
+			go func(i int, stub *fakes.ChaincodeStubInterface) {
+				defer wg.Done()
+				<-start
+				responses[i] = wasmContract.Invoke(stub)
+			}(i, stub)
+		}
+
+		close(start)
+		wg.Wait()
+
+		return responses
+	}
+
+	BeforeEach(func() {
+		contextStore = internal.NewContextStore()
+		proxy = internal.NewFabricProxy(contextStore)
+		proxy.SetCapabilities(internal.NewCapabilities(1, internal.CapabilityReadYourWrites))
+
+		wasmInvoker = &fakes.WasmGuestInvoker{}
+		wasmInvoker.InvokeWasmOperationStub = guest
+		wasmContract = internal.NewWasmContract(contextStore, wasmInvoker)
+
+		replayEntered = make(chan struct{})
+		replayRelease = make(chan struct{})
+	})
+
+	It("should keep the ledger operations of each transaction separate", func() {
+		// The same transaction IDs are used on every channel
+		stubs := make([]*fakes.ChaincodeStubInterface, transactions)
+		for i := range stubs {
+			stubs[i] = newStub(fmt.Sprintf("channel%d", i%channels), fmt.Sprintf("txn%d", i/channels), "create")
+		}
+
+		responses := invokeAll(stubs)
+
+		for i, stub := range stubs {
+			value := []byte(stub.GetChannelID() + "/" + stub.GetTxID())
+			Expect(responses[i].Status).To(Equal(int32(200)), responses[i].Message)
+			Expect(responses[i].Payload).To(Equal(value), "Should read its own write")
+
+			Expect(stub.PutStateCallCount()).To(Equal(1))
+			key, putValue := stub.PutStateArgsForCall(0)
+			Expect(key).To(Equal(stub.GetTxID()))
+			Expect(putValue).To(Equal(value))
+		}
+
+		Expect(contextStore.Count()).To(Equal(0))
+	})
+
+	It("should release the resources of each transaction", func() {
+		stubs := make([]*fakes.ChaincodeStubInterface, transactions)
+		iterators := make([]*fakes.StateQueryIteratorInterface, transactions)
+		for i := range stubs {
+			stubs[i] = newStub(fmt.Sprintf("channel%d", i%channels), fmt.Sprintf("txn%d", i/channels), "scan")
+			iterators[i] = &fakes.StateQueryIteratorInterface{}
+			stubs[i].GetStateByRangeReturns(iterators[i], nil)
+		}
+
+		responses := invokeAll(stubs)
+
+		for i := range stubs {
+			Expect(responses[i].Status).To(Equal(int32(200)), responses[i].Message)
+			Expect(iterators[i].CloseCallCount()).To(Equal(1), "Should close the iterator left open by the guest")
+		}
+
+		Expect(contextStore.Count()).To(Equal(0))
+	})
+
+	It("should clean up failed transactions", func() {
+		stubs := make([]*fakes.ChaincodeStubInterface, transactions)
+		for i := range stubs {
+			function := "create"
+			if i%2 == 0 {
+				function = "fail"
+			}
+			stubs[i] = newStub(fmt.Sprintf("channel%d", i%channels), fmt.Sprintf("txn%d", i), function)
+		}
+
+		responses := invokeAll(stubs)
+
+		for i, stub := range stubs {
+			if i%2 == 0 {
+				Expect(responses[i].Status).To(Equal(int32(500)))
+				Expect(responses[i].Message).To(Equal(fmt.Sprintf("Guest failed %s/%s", stub.GetChannelID(), stub.GetTxID())))
+			} else {
+				Expect(responses[i].Status).To(Equal(int32(200)), responses[i].Message)
+			}
+		}
+
+		Expect(contextStore.Count()).To(Equal(0))
+	})
+
+	It("should reject host calls once the transaction has ended", func() {
+		stub := newStub("channel1", "txn1", "create")
+		Expect(wasmContract.Invoke(stub).Status).To(Equal(int32(200)))
+
+		txContext := &contract.TransactionContext{ChannelId: "channel1", TransactionId: "txn1"}
+		err := hostCall("ReadState", &contract.ReadStateRequest{Context: txContext, StateKey: "txn1"}, &contract.ReadStateResponse{})
+		Expect(internal.ErrorCode(err)).To(Equal(hostapi.ErrorCode_INVALID_CONTEXT))
+	})
+
+	It("should reject a replayed transaction ID while the original is in flight", func() {
+		original := newStub("channel1", "txn1", "replay")
+		originalResponse := make(chan pb.Response, 1)
+		go func() {
+			originalResponse <- wasmContract.Invoke(original)
+		}()
+		Eventually(replayEntered).Should(Receive())
+
+		replays := make([]*fakes.ChaincodeStubInterface, transactions)
+		for i := range replays {
+			replays[i] = newStub("channel1", "txn1", "replay")
+		}
+
+		for _, response := range invokeAll(replays) {
+			Expect(response.Status).To(Equal(int32(500)))
+			Expect(response.Message).To(Equal("Stub already exists for transaction context channel1 txn1"))
+		}
+
+		Expect(contextStore.Count()).To(Equal(1), "Should not remove the original transaction context")
+
+		close(replayRelease)
+		response := <-originalResponse
+		Expect(response.Status).To(Equal(int32(200)), response.Message)
+		Expect(response.Payload).To(Equal([]byte("channel1/txn1")))
+		Expect(contextStore.Count()).To(Equal(0))
+	})
+})
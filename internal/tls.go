@@ -0,0 +1,83 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// TLSConfig is used to configure TLS for the chaincode server
+type TLSConfig struct {
+	// Disabled turns off TLS, so the connection from the peer is plaintext
+	Disabled bool `yaml:"disabled" env:"CHAINCODE_TLS_DISABLED" usage:"disable TLS for the chaincode server"`
+	// KeyFile is the path of the PEM encoded server private key
+	KeyFile string `yaml:"key" env:"CHAINCODE_TLS_KEY" usage:"PEM encoded server private key file" redact:"true"`
+	// CertFile is the path of the PEM encoded server certificate
+	CertFile string `yaml:"cert" env:"CHAINCODE_TLS_CERT" usage:"PEM encoded server certificate file"`
+	// ClientCACertFile is the optional path of the PEM encoded CA certificates
+	// used to verify peer client certificates for mutual TLS
+	ClientCACertFile string `yaml:"clientCACert" env:"CHAINCODE_CLIENT_CA_CERT" usage:"PEM encoded CA certificate file for mutual TLS"`
+}
+
+// LoadTLSProperties reads and validates the files specified in the TLS
+// configuration, and returns the TLS properties for the chaincode server
+func LoadTLSProperties(config TLSConfig) (shim.TLSProperties, error) {
+	if config.Disabled {
+		return shim.TLSProperties{Disabled: true}, nil
+	}
+
+	if config.KeyFile == "" || config.CertFile == "" {
+		return shim.TLSProperties{}, fmt.Errorf("TLS key and certificate files must be specified when TLS is enabled")
+	}
+
+	key, err := ioutil.ReadFile(config.KeyFile)
+	if err != nil {
+		return shim.TLSProperties{}, fmt.Errorf("Unable to read TLS key file: %s", err.Error())
+	}
+
+	cert, err := ioutil.ReadFile(config.CertFile)
+	if err != nil {
+		return shim.TLSProperties{}, fmt.Errorf("Unable to read TLS certificate file: %s", err.Error())
+	}
+
+	keyPair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		return shim.TLSProperties{}, fmt.Errorf("Invalid TLS key pair: %s", err.Error())
+	}
+
+	leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return shim.TLSProperties{}, fmt.Errorf("Invalid TLS certificate: %s", err.Error())
+	}
+
+	if time.Now().After(leaf.NotAfter) {
+		return shim.TLSProperties{}, fmt.Errorf("TLS certificate expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	props := shim.TLSProperties{
+		Key:  key,
+		Cert: cert,
+	}
+
+	if config.ClientCACertFile != "" {
+		clientCACerts, err := ioutil.ReadFile(config.ClientCACertFile)
+		if err != nil {
+			return shim.TLSProperties{}, fmt.Errorf("Unable to read TLS client CA certificate file: %s", err.Error())
+		}
+
+		if !x509.NewCertPool().AppendCertsFromPEM(clientCACerts) {
+			return shim.TLSProperties{}, fmt.Errorf("No valid certificates found in TLS client CA certificate file %s", config.ClientCACertFile)
+		}
+
+		props.ClientCACerts = clientCACerts
+	}
+
+	return props, nil
+}
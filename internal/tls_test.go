@@ -0,0 +1,128 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+)
+
+// writeKeyPair writes a self signed certificate and private key valid until
+// the specified time, returning the paths of the certificate and key files
+func writeKeyPair(dir string, name string, notAfter time.Time) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notAfter.Add(-48 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(privateKey)
+	Expect(err).NotTo(HaveOccurred())
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)).To(Succeed())
+
+	return certFile, keyFile
+}
+
+var _ = Describe("LoadTLSProperties", func() {
+	var (
+		tempDir  string
+		certFile string
+		keyFile  string
+		caFile   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "tls")
+		Expect(err).NotTo(HaveOccurred())
+
+		certFile, keyFile = writeKeyPair(tempDir, "server", time.Now().Add(24*time.Hour))
+		caFile, _ = writeKeyPair(tempDir, "ca", time.Now().Add(24*time.Hour))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	It("should disable TLS", func() {
+		props, err := internal.LoadTLSProperties(internal.TLSConfig{Disabled: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props.Disabled).To(BeTrue())
+	})
+
+	It("should load the server key and certificate", func() {
+		props, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: keyFile, CertFile: certFile})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props.Disabled).To(BeFalse())
+		Expect(props.Key).To(ContainSubstring("EC PRIVATE KEY"))
+		Expect(props.Cert).To(ContainSubstring("CERTIFICATE"))
+		Expect(props.ClientCACerts).To(BeNil())
+	})
+
+	It("should load the client CA certificate for mutual TLS", func() {
+		props, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: keyFile, CertFile: certFile, ClientCACertFile: caFile})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props.ClientCACerts).To(ContainSubstring("CERTIFICATE"))
+	})
+
+	It("should fail when the key or certificate is not specified", func() {
+		_, err := internal.LoadTLSProperties(internal.TLSConfig{CertFile: certFile})
+		Expect(err).To(MatchError("TLS key and certificate files must be specified when TLS is enabled"))
+	})
+
+	It("should fail when a file is missing", func() {
+		_, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: filepath.Join(tempDir, "missing.key"), CertFile: certFile})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("Unable to read TLS key file"))
+	})
+
+	It("should fail when the key does not match the certificate", func() {
+		_, otherKeyFile := writeKeyPair(tempDir, "other", time.Now().Add(24*time.Hour))
+
+		_, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: otherKeyFile, CertFile: certFile})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("Invalid TLS key pair"))
+	})
+
+	It("should fail when the certificate has expired", func() {
+		expiredCertFile, expiredKeyFile := writeKeyPair(tempDir, "expired", time.Now().Add(-time.Hour))
+
+		_, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: expiredKeyFile, CertFile: expiredCertFile})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("TLS certificate expired at"))
+	})
+
+	It("should fail when the client CA certificate file is invalid", func() {
+		_, err := internal.LoadTLSProperties(internal.TLSConfig{KeyFile: keyFile, CertFile: certFile, ClientCACertFile: keyFile})
+		Expect(err).To(MatchError("No valid certificates found in TLS client CA certificate file " + keyFile))
+	})
+})
@@ -0,0 +1,194 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/label"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+const tracerName = "github.com/hyperledgendary/fabric-chaincode-wasm"
+
+// Span attribute keys
+const (
+	ChannelAttribute    = label.Key("fabric.channel")
+	TxIDAttribute       = label.Key("fabric.txid")
+	FunctionAttribute   = label.Key("fabric.function")
+	OperationAttribute  = label.Key("wasm.operation")
+	KeyAttribute        = label.Key("fabric.key")
+	CollectionAttribute = label.Key("fabric.collection")
+)
+
+// TracingConfig is used to configure span export
+type TracingConfig struct {
+	// Exporter is either otlp, file, or empty to disable tracing
+	Exporter string `yaml:"exporter" env:"CHAINCODE_TRACING_EXPORTER" usage:"trace exporter: otlp, file, or empty to disable tracing"`
+	// Endpoint is the host and port of the OTLP collector
+	Endpoint string `yaml:"endpoint" env:"CHAINCODE_TRACING_ENDPOINT" usage:"host and port of the OTLP collector"`
+	// Insecure disables TLS for the OTLP collector connection
+	Insecure bool `yaml:"insecure" env:"CHAINCODE_TRACING_INSECURE" usage:"disable TLS for the OTLP collector connection"`
+	// File is the path of the file to write spans to with the file exporter
+	File string `yaml:"file" env:"CHAINCODE_TRACING_FILE" usage:"file to write spans to with the file exporter"`
+	// ServiceName identifies the Wasm chaincode in exported spans
+	ServiceName string `yaml:"-"`
+}
+
+// exporter returns the name of the trace exporter, which is not case
+// sensitive
+func (config TracingConfig) exporter() string {
+	return strings.ToLower(config.Exporter)
+}
+
+// InitTracing configures the global tracer provider to export spans, and
+// returns a function to flush and stop span export
+func InitTracing(config TracingConfig) (func(context.Context) error, error) {
+	var exporter export.SpanExporter
+	var traceFile *os.File
+	var err error
+
+	switch config.exporter() {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		if config.Endpoint == "" {
+			return nil, fmt.Errorf("No endpoint specified for OTLP trace exporter")
+		}
+
+		options := []otlp.ExporterOption{otlp.WithAddress(config.Endpoint)}
+		if config.Insecure {
+			options = append(options, otlp.WithInsecure())
+		} else {
+			options = append(options, otlp.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		}
+
+		exporter, err = otlp.NewExporter(options...)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create OTLP trace exporter: %s", err.Error())
+		}
+	case "file":
+		if config.File == "" {
+			return nil, fmt.Errorf("No file specified for file trace exporter")
+		}
+
+		traceFile, err = os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to open trace file: %s", err.Error())
+		}
+
+		exporter, err = stdout.NewExporter(stdout.WithWriter(traceFile), stdout.WithoutMetricExport())
+		if err != nil {
+			traceFile.Close()
+			return nil, fmt.Errorf("Unable to create file trace exporter: %s", err.Error())
+		}
+	default:
+		return nil, fmt.Errorf("Invalid trace exporter %s", config.Exporter)
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = "fabric-chaincode-wasm"
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	shutdown := func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if traceFile != nil {
+			traceFile.Close()
+		}
+
+		return err
+	}
+
+	return shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// contextAttributes returns the span attributes which identify a transaction context
+func contextAttributes(context *contract.TransactionContext) []label.KeyValue {
+	return []label.KeyValue{
+		ChannelAttribute.String(context.GetChannelId()),
+		TxIDAttribute.String(context.GetTransactionId()),
+	}
+}
+
+type transactionFunctionKey struct{}
+
+// withTransactionFunction returns a copy of ctx which names the transaction
+// function being run by the Wasm guest, so that it can be added to the spans
+// for guest operations and host calls
+func withTransactionFunction(ctx context.Context, function string) context.Context {
+	return context.WithValue(ctx, transactionFunctionKey{}, function)
+}
+
+// functionAttributes returns the span attribute which names the transaction
+// function being run by the Wasm guest, if ctx names one
+func functionAttributes(ctx context.Context) []label.KeyValue {
+	function, ok := ctx.Value(transactionFunctionKey{}).(string)
+	if !ok {
+		return nil
+	}
+
+	return []label.KeyValue{FunctionAttribute.String(function)}
+}
+
+// transactionAttributes returns the span attributes which identify the
+// transaction being run by the Wasm guest, if ctx identifies one
+func transactionAttributes(ctx context.Context) []label.KeyValue {
+	var attributes []label.KeyValue
+	if txContext := TransactionContextFrom(ctx); txContext != nil {
+		attributes = append(attributes, contextAttributes(txContext)...)
+	}
+
+	return append(attributes, functionAttributes(ctx)...)
+}
+
+// traceRequest adds the transaction context, key and collection of a ledger
+// request to the current span
+func traceRequest(ctx context.Context, txContext *contract.TransactionContext, key string, collection *contract.Collection) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(contextAttributes(txContext)...)
+	if key != "" {
+		span.SetAttributes(KeyAttribute.String(key))
+	}
+	if collection.GetName() != "" {
+		span.SetAttributes(CollectionAttribute.String(collection.GetName()))
+	}
+}
+
+// endSpan records the outcome of an operation and ends the span
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,186 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+)
+
+// tracedInstance is a waPC instance which completes every transaction
+type tracedInstance struct{}
+
+func (instance *tracedInstance) Invoke(ctx context.Context, operation string, payload []byte) ([]byte, error) {
+	return proto.Marshal(&contract.InvokeTransactionResponse{})
+}
+
+func (instance *tracedInstance) Close() {}
+
+var _ = Describe("Tracing", func() {
+	var (
+		spanRecorder *oteltest.StandardSpanRecorder
+	)
+
+	BeforeEach(func() {
+		spanRecorder = &oteltest.StandardSpanRecorder{}
+		otel.SetTracerProvider(oteltest.NewTracerProvider(oteltest.WithSpanRecorder(spanRecorder)))
+	})
+
+	AfterEach(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	Describe("WasmContract.Invoke", func() {
+		var (
+			wasmContract *internal.WasmContract
+			wasmInvoker  *fakes.WasmGuestInvoker
+			stub         *fakes.ChaincodeStubInterface
+		)
+
+		BeforeEach(func() {
+			contextStore := internal.NewContextStore()
+			proxy := internal.NewFabricProxy(contextStore)
+			wasmInvoker = &fakes.WasmGuestInvoker{}
+			wasmContract = internal.NewWasmContract(contextStore, wasmInvoker)
+
+			stub = &fakes.ChaincodeStubInterface{}
+			stub.GetChannelIDReturns("channel1")
+			stub.GetTxIDReturns("tracetxn1")
+			stub.GetFunctionAndParametersReturns("ReadCar", nil)
+			stub.GetPrivateDataReturns([]byte("bond"), nil)
+
+			wasmInvoker.InvokeWasmOperationStub = func(ctx context.Context, operation string, payload []byte) ([]byte, error) {
+				request := &contract.ReadStateRequest{
+					Context:    &contract.TransactionContext{ChannelId: "channel1", TransactionId: "tracetxn1"},
+					Collection: &contract.Collection{Name: "secrets"},
+					StateKey:   "007",
+				}
+				requestPayload, _ := proto.Marshal(request)
+
+				return proxy.FabricCall(ctx, "wapc", "LedgerService", "ReadState", requestPayload)
+			}
+		})
+
+		It("should create a span for the transaction with a child span for each FabricCall", func() {
+			wasmContract.Invoke(stub)
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(2))
+
+			fabricCallSpan := spans[0]
+			invokeSpan := spans[1]
+
+			Expect(invokeSpan.Name()).To(Equal("WasmContract.Invoke"))
+			Expect(invokeSpan.SpanKind()).To(Equal(trace.SpanKindServer))
+			Expect(invokeSpan.Attributes()).To(Equal(map[label.Key]label.Value{
+				internal.ChannelAttribute:  label.StringValue("channel1"),
+				internal.TxIDAttribute:     label.StringValue("tracetxn1"),
+				internal.FunctionAttribute: label.StringValue("ReadCar"),
+			}))
+
+			Expect(fabricCallSpan.Name()).To(Equal("LedgerService.ReadState"))
+			Expect(fabricCallSpan.ParentSpanID()).To(Equal(invokeSpan.SpanContext().SpanID))
+			Expect(fabricCallSpan.Attributes()).To(Equal(map[label.Key]label.Value{
+				internal.OperationAttribute:  label.StringValue("ReadState"),
+				internal.FunctionAttribute:   label.StringValue("ReadCar"),
+				internal.ChannelAttribute:    label.StringValue("channel1"),
+				internal.TxIDAttribute:       label.StringValue("tracetxn1"),
+				internal.KeyAttribute:        label.StringValue("007"),
+				internal.CollectionAttribute: label.StringValue("secrets"),
+			}))
+		})
+
+		It("should record errors on the spans", func() {
+			stub.GetPrivateDataReturns(nil, nil)
+
+			wasmContract.Invoke(stub)
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].StatusCode()).To(Equal(codes.Error))
+			Expect(spans[0].StatusMessage()).To(Equal("ReadState failed for collection secrets: State 007 does not exist"))
+			Expect(spans[1].StatusCode()).To(Equal(codes.Error))
+		})
+	})
+
+	Describe("WasmGuest.InvokeWasmOperation", func() {
+		It("should identify the transaction on the guest operation span", func() {
+			contextStore := internal.NewContextStore()
+			wasmGuest, err := internal.NewTestWasmGuest(internal.PoolConfig{Size: 1, Timeout: time.Second}, func() internal.WasmInstance {
+				return &tracedInstance{}
+			})
+			Expect(err).NotTo(HaveOccurred())
+			wasmContract := internal.NewWasmContract(contextStore, wasmGuest)
+
+			stub := &fakes.ChaincodeStubInterface{}
+			stub.GetChannelIDReturns("channel1")
+			stub.GetTxIDReturns("tracetxn2")
+			stub.GetFunctionAndParametersReturns("QueryCars", nil)
+
+			Expect(wasmContract.Invoke(stub).Status).To(Equal(int32(200)))
+
+			spans := spanRecorder.Completed()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name()).To(Equal("InvokeWasmOperation"))
+			Expect(spans[0].Attributes()).To(Equal(map[label.Key]label.Value{
+				internal.OperationAttribute: label.StringValue("InvokeTransaction"),
+				internal.ChannelAttribute:   label.StringValue("channel1"),
+				internal.TxIDAttribute:      label.StringValue("tracetxn2"),
+				internal.FunctionAttribute:  label.StringValue("QueryCars"),
+			}))
+		})
+	})
+
+	Describe("InitTracing", func() {
+		It("should do nothing without an exporter", func() {
+			shutdown, err := internal.InitTracing(internal.TracingConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shutdown(context.Background())).To(Succeed())
+		})
+
+		It("should fail with an unknown exporter", func() {
+			_, err := internal.InitTracing(internal.TracingConfig{Exporter: "carrier-pigeon"})
+			Expect(err).To(MatchError("Invalid trace exporter carrier-pigeon"))
+		})
+
+		It("should fail without an OTLP endpoint", func() {
+			_, err := internal.InitTracing(internal.TracingConfig{Exporter: "otlp"})
+			Expect(err).To(MatchError("No endpoint specified for OTLP trace exporter"))
+		})
+
+		It("should export spans to a file", func() {
+			tempDir, err := ioutil.TempDir("", "tracing")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tempDir)
+			traceFile := filepath.Join(tempDir, "spans.json")
+
+			shutdown, err := internal.InitTracing(internal.TracingConfig{Exporter: "file", File: traceFile})
+			Expect(err).NotTo(HaveOccurred())
+
+			proxy := internal.NewFabricProxy(internal.NewContextStore())
+			proxy.FabricCall(context.Background(), "wapc", "LedgerService", "ExistsState", nil)
+			Expect(shutdown(context.Background())).To(Succeed())
+
+			spans, err := ioutil.ReadFile(traceFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(spans)).To(ContainSubstring("LedgerService.ExistsState"))
+		})
+	})
+})
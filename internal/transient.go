@@ -0,0 +1,45 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// CapabilityTransientData is the capability for guests to read transient data
+// entries with the GetTransient host call, instead of receiving the whole
+// transient data map in every InvokeTransactionRequest
+const CapabilityTransientData = "TransientData"
+
+func (proxy *FabricProxy) getTransient(ctx context.Context, payload []byte) ([]byte, error) {
+	request := &hostapi.GetTransientRequest{}
+	err := proto.Unmarshal(payload, request)
+	if err != nil {
+		return nil, invalidRequest(err)
+	}
+
+	context := request.GetContext()
+	key := request.GetKey()
+	logger.Debug("GetTransient", append(contextFields(context), zap.String("key", key))...)
+	traceRequest(ctx, context, key, nil)
+
+	stub, err := proxy.transactionStub(context)
+	if err != nil {
+		return nil, wrapOperationError("GetTransient", "", key, err)
+	}
+
+	transientMap, err := stub.GetTransient()
+	if err != nil {
+		return nil, wrapOperationError("GetTransient", "", key, err)
+	}
+
+	response := &hostapi.GetTransientResponse{}
+	response.Value, response.Exists = transientMap[key]
+
+	return proto.Marshal(response)
+}
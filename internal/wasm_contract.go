@@ -4,11 +4,14 @@
 package internal
 
 import (
-	"log"
+	"context"
+	"time"
 
 	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -18,6 +21,8 @@ import (
 type WasmContract struct {
 	contextStore     *ContextStore
 	wasmGuestInvoker WasmGuestInvoker
+	manifest         *PackageManifest
+	capabilities     *Capabilities
 }
 
 // NewWasmContract returns a new smart contract to invoke Wasm transactions
@@ -29,6 +34,18 @@ func NewWasmContract(contextStore *ContextStore, invoker WasmGuestInvoker) *Wasm
 	return &contract
 }
 
+// SetManifest sets the package manifest, which declares the transactions
+// which must not write to the ledger
+func (wc *WasmContract) SetManifest(manifest *PackageManifest) {
+	wc.manifest = manifest
+}
+
+// SetCapabilities sets the capabilities negotiated with the Wasm guest, which
+// must be done before any transactions are invoked
+func (wc *WasmContract) SetCapabilities(capabilities *Capabilities) {
+	wc.capabilities = capabilities
+}
+
 // Init does nothing
 func (wc *WasmContract) Init(APIstub shim.ChaincodeStubInterface) pb.Response {
 	return shim.Success(nil)
@@ -36,7 +53,21 @@ func (wc *WasmContract) Init(APIstub shim.ChaincodeStubInterface) pb.Response {
 
 // Invoke calls a Wasm transaction
 func (wc *WasmContract) Invoke(APIstub shim.ChaincodeStubInterface) pb.Response {
-	result, err := wc.callTransaction(APIstub)
+	start := time.Now()
+	function, _ := APIstub.GetFunctionAndParameters()
+
+	ctx, span := tracer().Start(context.Background(), "WasmContract.Invoke",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			ChannelAttribute.String(APIstub.GetChannelID()),
+			TxIDAttribute.String(APIstub.GetTxID()),
+			FunctionAttribute.String(function),
+		),
+	)
+
+	result, err := wc.callTransaction(ctx, APIstub)
+	endSpan(span, err)
+	observeDuration(transactionDuration.WithLabelValues(wc.functionLabel(function), outcome(err)), start)
 	if err != nil {
 		return shim.Error(err.Error())
 	}
@@ -44,41 +75,72 @@ func (wc *WasmContract) Invoke(APIstub shim.ChaincodeStubInterface) pb.Response
 	return shim.Success(result)
 }
 
-func (wc *WasmContract) callTransaction(APIstub shim.ChaincodeStubInterface) ([]byte, error) {
+// functionLabel returns the metrics label for a transaction function, which
+// is only the function name if it is listed in the package manifest
+func (wc *WasmContract) functionLabel(function string) string {
+	if wc.manifest.HasTransaction(function) {
+		return function
+	}
+
+	return otherLabel
+}
+
+func (wc *WasmContract) callTransaction(ctx context.Context, APIstub shim.ChaincodeStubInterface) ([]byte, error) {
 	txID := APIstub.GetTxID()
 	channelID := APIstub.GetChannelID()
+	log := logger.With(zap.String("channel", channelID), zap.String("txid", txID))
 
 	err := wc.contextStore.Put(channelID, txID, APIstub)
 	if err != nil {
-		log.Printf("[host] error putting stub for context chid %s txid %s: %s\n", channelID, txID, err)
+		log.Error("Error putting stub", zap.Error(err))
 		return nil, err
 	}
 	defer func() {
 		err := wc.contextStore.Remove(channelID, txID)
 		if err != nil {
-			log.Printf("[host] error removing stub for context chid %s txid %s: %s\n", channelID, txID, err)
+			log.Error("Error removing stub", zap.Error(err))
 		}
 	}()
 
 	function, params := APIstub.GetFunctionAndParameters()
+	log = log.With(zap.String("function", function))
 
-	transientMap, err := APIstub.GetTransient()
-	if err != nil {
-		log.Printf("[host] error creating invoke transaction request message: %s\n", err)
-		return nil, err
+	if wc.manifest.IsReadOnly(function) {
+		log.Debug("Read-only transaction")
+		err = wc.contextStore.SetReadOnly(channelID, txID, function)
+		if err != nil {
+			log.Error("Error marking transaction read-only", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	// Guests which read transient data with the GetTransient host call do
+	// not need the whole transient data map in every request
+	var transientMap map[string][]byte
+	if !wc.capabilities.Has(CapabilityTransientData) {
+		transientMap, err = APIstub.GetTransient()
+		if err != nil {
+			log.Error("Error getting transient data", zap.Error(err))
+			return nil, err
+		}
 	}
 
-	log.Printf("[host] calling %s with context chid %s txid %s\n", function, channelID, txID)
+	log.Debug("Calling transaction")
 
 	args, err := createInvokeTransactionArgs(channelID, txID, function, params, transientMap)
 	if err != nil {
-		log.Printf("[host] error creating invoke transaction request message: %s\n", err)
+		log.Error("Error creating invoke transaction request message", zap.Error(err))
 		return nil, err
 	}
 
-	result, err := wc.wasmGuestInvoker.InvokeWasmOperation("InvokeTransaction", args)
+	// The transaction context lets the host tag guest log messages and spans
+	ctx = WithTransactionContext(ctx, &contract.TransactionContext{ChannelId: channelID, TransactionId: txID})
+	ctx = withTransactionFunction(ctx, function)
+
+	start := time.Now()
+	result, err := wc.wasmGuestInvoker.InvokeWasmOperation(ctx, "InvokeTransaction", args)
 	if err != nil {
-		log.Printf("[host] error invoking transaction: %s\n", err)
+		log.Warn("Transaction failed", zap.Duration("duration", time.Since(start)), zap.Error(err))
 		return nil, err
 	}
 
@@ -86,7 +148,7 @@ func (wc *WasmContract) callTransaction(APIstub shim.ChaincodeStubInterface) ([]
 	err = proto.Unmarshal(result, response)
 	responsePayload := response.GetPayload()
 
-	log.Printf("[host] success result=%s\n", string(responsePayload))
+	log.Info("Transaction succeeded", zap.Duration("duration", time.Since(start)), zap.Int("payloadLength", len(responsePayload)))
 	return responsePayload, nil
 }
 
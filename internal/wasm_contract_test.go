@@ -4,6 +4,8 @@
 package internal_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
@@ -15,12 +17,13 @@ import (
 
 var _ = Describe("WasmContract", func() {
 	var (
+		contextStore *internal.ContextStore
 		wasmContract *internal.WasmContract
 		wasmInvoker  *fakes.WasmGuestInvoker
 	)
 
 	BeforeEach(func() {
-		contextStore := internal.NewContextStore()
+		contextStore = internal.NewContextStore()
 		wasmInvoker = &fakes.WasmGuestInvoker{}
 
 		wasmContract = internal.NewWasmContract(contextStore, wasmInvoker)
@@ -46,6 +49,18 @@ var _ = Describe("WasmContract", func() {
 				Expect(result.Status).To(Equal(int32(200)))
 				Expect(result.Payload).To(Equal([]byte("bond")))
 			})
+
+			It("should identify the transaction to the Wasm guest invoker", func() {
+				stub.GetChannelIDReturns("channel1")
+				stub.GetTxIDReturns("txn1")
+
+				wasmContract.Invoke(stub)
+
+				ctx, _, _ := wasmInvoker.InvokeWasmOperationArgsForCall(0)
+				txContext := internal.TransactionContextFrom(ctx)
+				Expect(txContext.GetChannelId()).To(Equal("channel1"))
+				Expect(txContext.GetTransactionId()).To(Equal("txn1"))
+			})
 		})
 
 		Context("With transient data", func() {
@@ -66,7 +81,7 @@ var _ = Describe("WasmContract", func() {
 
 				Expect(result.Status).To(Equal(int32(200)))
 
-				operation, args := wasmInvoker.InvokeWasmOperationArgsForCall(0)
+				_, operation, args := wasmInvoker.InvokeWasmOperationArgsForCall(0)
 				Expect(operation).To(Equal("InvokeTransaction"))
 				Expect(args).NotTo(BeNil())
 
@@ -77,6 +92,61 @@ var _ = Describe("WasmContract", func() {
 				Expect(transientData).To(HaveKeyWithValue("pin", []byte("0000")))
 				Expect(transientData).To(HaveKeyWithValue("ssn", []byte("0123456789")))
 			})
+
+			It("should not include the transient data if the guest reads it with host calls", func() {
+				wasmContract.SetCapabilities(internal.NewCapabilities(internal.HostABIVersion, internal.CapabilityTransientData))
+
+				result := wasmContract.Invoke(stub)
+				Expect(result.Status).To(Equal(int32(200)))
+				Expect(stub.GetTransientCallCount()).To(Equal(0), "Should not call GetTransient")
+
+				_, _, args := wasmInvoker.InvokeWasmOperationArgsForCall(0)
+				itr := &contract.InvokeTransactionRequest{}
+				_ = proto.Unmarshal(args, itr)
+				Expect(itr.GetTransientArgs()).To(BeEmpty())
+			})
+		})
+
+		Context("With a read-only transaction", func() {
+			var (
+				proxy *internal.FabricProxy
+				stub  *fakes.ChaincodeStubInterface
+			)
+
+			BeforeEach(func() {
+				proxy = internal.NewFabricProxy(contextStore)
+				wasmContract.SetManifest(&internal.PackageManifest{ReadOnlyTransactions: []string{"Query*"}})
+
+				stub = &fakes.ChaincodeStubInterface{}
+				stub.GetChannelIDReturns("channel1")
+				stub.GetTxIDReturns("querytxn1")
+				stub.GetFunctionAndParametersReturns("QueryCar", nil)
+
+				wasmInvoker.InvokeWasmOperationStub = func(ctx context.Context, operation string, payload []byte) ([]byte, error) {
+					request := &contract.CreateStateRequest{
+						Context: &contract.TransactionContext{ChannelId: "channel1", TransactionId: "querytxn1"},
+						State:   &contract.State{Key: "007", Value: []byte("bond")},
+					}
+					requestPayload, _ := proto.Marshal(request)
+
+					return proxy.FabricCall(ctx, "wapc", "LedgerService", "CreateState", requestPayload)
+				}
+			})
+
+			It("should reject write operations", func() {
+				result := wasmContract.Invoke(stub)
+				Expect(result.Status).To(Equal(int32(500)))
+				Expect(result.Message).To(Equal("CreateState failed: Transaction QueryCar is read-only and cannot write to the ledger"))
+				Expect(stub.PutStateCallCount()).To(Equal(0))
+			})
+
+			It("should allow write operations in other transactions", func() {
+				stub.GetFunctionAndParametersReturns("CreateCar", nil)
+
+				result := wasmContract.Invoke(stub)
+				Expect(result.Status).To(Equal(int32(200)))
+				Expect(stub.PutStateCallCount()).To(Equal(1))
+			})
 		})
 	})
 })
@@ -8,88 +8,257 @@ package internal
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
-	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
 	"github.com/wapc/wapc-go"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
+// PoolConfig is used to configure the pool of waPC instances
+type PoolConfig struct {
+	// Size is the number of waPC instances in the pool, which limits the
+	// number of concurrent Wasm operations
+	Size int `yaml:"size" env:"CHAINCODE_POOL_SIZE" usage:"number of waPC instances in the pool"`
+	// Timeout is how long to wait for a waPC instance from the pool
+	Timeout time.Duration `yaml:"timeout" env:"CHAINCODE_POOL_TIMEOUT" usage:"how long to wait for a waPC instance from the pool"`
+}
+
 // WasmGuestInvoker is the interface that wraps the InvokeWasmOperation method.
 //counterfeiter:generate -o fakes/wapc_guest_invoker.go --fake-name WasmGuestInvoker . WasmGuestInvoker
 type WasmGuestInvoker interface {
-	InvokeWasmOperation(operation string, payload []byte) ([]byte, error)
+	InvokeWasmOperation(ctx context.Context, operation string, payload []byte) ([]byte, error)
 }
 
 // WasmGuest encapsulates external dependencies required to invoke operations
 // in Wasm guest code. Currently this uses a pool of waPC instances.
 type WasmGuest struct {
-	wapcModule *wapc.Module
-	wapcPool   *wapc.Pool
+	// accessed atomically, so must be 64-bit aligned
+	inUse        int64
+	lastReturned int64
+
+	poolSize     int
+	poolTimeout  time.Duration
+	instances    chan *guestInstance
+	capabilities *Capabilities
+
+	// closeLock guards closed, so that instances returned after the guest
+	// has been closed are closed rather than returned to the pool
+	closeLock sync.Mutex
+	closed    bool
 }
 
-func consoleLog(msg string) {
-	fmt.Println(msg)
+// wasmInstance is the part of a waPC instance used by the pool
+type wasmInstance interface {
+	Invoke(ctx context.Context, operation string, payload []byte) ([]byte, error)
+	Close()
 }
 
-// NewWasmGuest returns a new WasmGuest capable of invoking Wasm operations
-func NewWasmGuest(wasmFile string, proxy *FabricProxy) (*WasmGuest, error) {
-	wg := &WasmGuest{}
+// guestInstance is a waPC instance in the pool. The waPC console logger is
+// only passed the message, so each instance has its own module and console
+// logger, which tags messages with the transaction the instance is running
+type guestInstance struct {
+	module      *wapc.Module
+	instance    wasmInstance
+	transaction atomic.Value
+}
+
+func newGuestInstance(wasmPackage *WasmPackage, proxy *FabricProxy) (*guestInstance, error) {
+	gi := &guestInstance{}
+	gi.setTransaction(nil)
 
-	wasmBytes, err := ioutil.ReadFile(wasmFile)
+	module, err := wapc.New(func(msg string) {
+		proxy.ConsoleLog(gi.currentTransaction(), msg)
+	}, wasmPackage.Module, proxy.FabricCall)
 	if err != nil {
 		return nil, err
 	}
+	gi.module = module
 
-	module, err := wapc.New(consoleLog, wasmBytes, proxy.FabricCall)
+	instance, err := module.Instantiate()
 	if err != nil {
+		module.Close()
 		return nil, err
 	}
-	wg.wapcModule = module
+	gi.instance = instance
+
+	return gi, nil
+}
+
+func (gi *guestInstance) setTransaction(context *contract.TransactionContext) {
+	gi.transaction.Store(context)
+}
+
+func (gi *guestInstance) currentTransaction() *contract.TransactionContext {
+	return gi.transaction.Load().(*contract.TransactionContext)
+}
+
+func (gi *guestInstance) close() {
+	gi.instance.Close()
+	if gi.module != nil {
+		gi.module.Close()
+	}
+}
 
-	pool, err := wapc.NewPool(module, 10)
+// NewWasmGuest returns a new WasmGuest capable of invoking Wasm operations.
+// The Wasm module is compiled for each waPC instance in the pool
+func NewWasmGuest(wasmPackage *WasmPackage, proxy *FabricProxy, config PoolConfig) (*WasmGuest, error) {
+	wg, err := newWasmGuest(config, func() (*guestInstance, error) {
+		return newGuestInstance(wasmPackage, proxy)
+	})
 	if err != nil {
 		return nil, err
 	}
-	wg.wapcPool = pool
+
+	capabilities, err := Handshake(wg)
+	if err != nil {
+		wg.Close()
+		return nil, err
+	}
+	wg.capabilities = capabilities
+	proxy.SetCapabilities(capabilities)
 
 	return wg, nil
 }
 
-// InvokeWasmOperation invoke a Wasm guest operation
-func (wg *WasmGuest) InvokeWasmOperation(operation string, payload []byte) (result []byte, err error) {
-	log.Printf("[host] Getting waPC Instance\n")
-	wapcInstance, err := wg.wapcPool.Get(10 * time.Millisecond)
-	if err != nil {
-		log.Printf("[host] error getting waPC instance: %s\n", err)
-		return nil, err
+// newWasmGuest returns a WasmGuest with a pool of instances created by
+// newInstance
+func newWasmGuest(config PoolConfig, newInstance func() (*guestInstance, error)) (*WasmGuest, error) {
+	if config.Size < 1 {
+		return nil, fmt.Errorf("Invalid waPC pool size %d", config.Size)
+	}
+
+	wg := &WasmGuest{
+		poolSize:    config.Size,
+		poolTimeout: config.Timeout,
+		instances:   make(chan *guestInstance, config.Size),
 	}
-	defer func() {
-		log.Printf("[host] Returning waPC Instance\n")
-		err = wg.wapcPool.Return(wapcInstance)
 
+	for i := 0; i < config.Size; i++ {
+		instance, err := newInstance()
 		if err != nil {
-			log.Printf("[host] error returning waPC instance: %s\n", err)
+			wg.Close()
+			return nil, err
 		}
+
+		wg.instances <- instance
+	}
+	atomic.StoreInt64(&wg.lastReturned, time.Now().UnixNano())
+
+	return wg, nil
+}
+
+// Capabilities returns the capabilities negotiated with the Wasm guest
+func (wg *WasmGuest) Capabilities() *Capabilities {
+	return wg.capabilities
+}
+
+// PoolStats returns the current state of the waPC instance pool
+func (wg *WasmGuest) PoolStats() PoolStats {
+	return PoolStats{
+		Size:         wg.poolSize,
+		InUse:        int(atomic.LoadInt64(&wg.inUse)),
+		LastReturned: time.Unix(0, atomic.LoadInt64(&wg.lastReturned)),
+	}
+}
+
+// getInstance gets a waPC instance from the pool, waiting up to the pool
+// timeout for one to be returned
+func (wg *WasmGuest) getInstance() (*guestInstance, error) {
+	select {
+	case instance := <-wg.instances:
+		return instance, nil
+	default:
+	}
+
+	timer := time.NewTimer(wg.poolTimeout)
+	defer timer.Stop()
+
+	select {
+	case instance := <-wg.instances:
+		return instance, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("get from pool timed out after %s", wg.poolTimeout)
+	}
+}
+
+// InvokeWasmOperation invoke a Wasm guest operation
+func (wg *WasmGuest) InvokeWasmOperation(ctx context.Context, operation string, payload []byte) (result []byte, err error) {
+	attributes := append([]label.KeyValue{OperationAttribute.String(operation)}, transactionAttributes(ctx)...)
+	ctx, span := tracer().Start(ctx, "InvokeWasmOperation", trace.WithAttributes(attributes...))
+	defer func() {
+		endSpan(span, err)
 	}()
 
-	ctx := context.TODO()
+	logger.Debug("Getting waPC instance", zap.String("operation", operation))
+	poolStart := time.Now()
+	instance, err := wg.getInstance()
+	observeDuration(poolWaitDuration, poolStart)
+	if err != nil {
+		poolExhaustedTotal.Inc()
+		logger.Error("Error getting waPC instance", zap.String("operation", operation), zap.Error(err))
+		return nil, err
+	}
+	atomic.AddInt64(&wg.inUse, 1)
+	instance.setTransaction(TransactionContextFrom(ctx))
+	defer func() {
+		logger.Debug("Returning waPC instance", zap.String("operation", operation))
+		instance.setTransaction(nil)
+		wg.returnInstance(instance)
+		atomic.AddInt64(&wg.inUse, -1)
+		atomic.StoreInt64(&wg.lastReturned, time.Now().UnixNano())
+	}()
 
-	log.Printf("[host] Invoking operation %s\n", operation)
-	result, err = wapcInstance.Invoke(ctx, operation, payload)
+	start := time.Now()
+	result, err = instance.instance.Invoke(ctx, operation, payload)
 	if err != nil {
-		log.Printf("[host] error invoking transaction: %s\n", err)
+		if isGuestTrap(err) {
+			guestTrapsTotal.Inc()
+		}
+		logger.Error("Error invoking Wasm operation", zap.String("operation", operation), zap.Duration("duration", time.Since(start)), zap.Error(err))
 		return nil, err
 	}
 
+	logger.Debug("Invoked Wasm operation", zap.String("operation", operation), zap.Duration("duration", time.Since(start)))
 	return result, nil
 }
 
-// Close closes the WasmGuest, rendering it unusable for invoking further operations
+// returnInstance returns an instance to the pool, or closes it if the
+// WasmGuest has been closed
+func (wg *WasmGuest) returnInstance(instance *guestInstance) {
+	wg.closeLock.Lock()
+	defer wg.closeLock.Unlock()
+
+	if wg.closed {
+		logger.Debug("Closing returned waPC instance")
+		instance.close()
+		return
+	}
+
+	wg.instances <- instance
+}
+
+// Close closes the WasmGuest, rendering it unusable for invoking further
+// operations. Only idle instances are closed immediately, since closing an
+// instance which is still running an operation, for example when shutdown
+// stops waiting for in-flight transactions, would free memory the guest is
+// still using. Busy instances are closed when their operation returns
 func (wg *WasmGuest) Close() {
-	log.Printf("[host] Closing waPC Pool")
-	wg.wapcPool.Close()
+	wg.closeLock.Lock()
+	wg.closed = true
+	wg.closeLock.Unlock()
 
-	log.Printf("[host] Closing waPC Module")
-	wg.wapcModule.Close()
+	logger.Info("Closing idle waPC instances", zap.Int64("inUse", atomic.LoadInt64(&wg.inUse)))
+	for {
+		select {
+		case instance := <-wg.instances:
+			instance.close()
+		default:
+			return
+		}
+	}
 }
@@ -0,0 +1,105 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal/fakes"
+	contract "github.com/hyperledgendary/fabric-ledger-protos-go/contract"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/protobuf/proto"
+)
+
+// blockingInstance is a waPC instance which runs transactions until they are
+// released
+type blockingInstance struct {
+	entered chan struct{}
+	release chan struct{}
+	closed  int32
+}
+
+func (instance *blockingInstance) Invoke(ctx context.Context, operation string, payload []byte) ([]byte, error) {
+	instance.entered <- struct{}{}
+	<-instance.release
+
+	return proto.Marshal(&contract.InvokeTransactionResponse{Payload: []byte("bond")})
+}
+
+func (instance *blockingInstance) Close() {
+	atomic.AddInt32(&instance.closed, 1)
+}
+
+func (instance *blockingInstance) closeCount() int32 {
+	return atomic.LoadInt32(&instance.closed)
+}
+
+var _ = Describe("WasmGuest", func() {
+	var (
+		wasmGuest *internal.WasmGuest
+		instances []*blockingInstance
+		entered   chan struct{}
+		release   chan struct{}
+	)
+
+	BeforeEach(func() {
+		instances = nil
+		entered = make(chan struct{}, 2)
+		release = make(chan struct{})
+
+		var err error
+		wasmGuest, err = internal.NewTestWasmGuest(internal.PoolConfig{Size: 2, Timeout: time.Second}, func() internal.WasmInstance {
+			instance := &blockingInstance{entered: entered, release: release}
+			instances = append(instances, instance)
+			return instance
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not close instances which are still running after a drain times out", func() {
+		contextStore := internal.NewContextStore()
+		wasmContract := internal.NewWasmContract(contextStore, wasmGuest)
+
+		stub := &fakes.ChaincodeStubInterface{}
+		stub.GetChannelIDReturns("channel1")
+		stub.GetTxIDReturns("txn1")
+		stub.GetFunctionAndParametersReturns("ReadCar", nil)
+
+		response := make(chan pb.Response, 1)
+		go func() {
+			response <- wasmContract.Invoke(stub)
+		}()
+		Eventually(entered).Should(Receive())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		Expect(contextStore.Drain(ctx)).To(MatchError("Stopped waiting for 1 in-flight transactions: context deadline exceeded"))
+
+		wasmGuest.Close()
+		// The pool hands out instances in the order they were created
+		busy, idle := instances[0], instances[1]
+		Expect(idle.closeCount()).To(Equal(int32(1)), "Should close idle instances")
+		Expect(busy.closeCount()).To(Equal(int32(0)), "Should not close an instance which is running a transaction")
+
+		close(release)
+		result := <-response
+		Expect(result.Status).To(Equal(int32(200)), result.Message)
+		Expect(result.Payload).To(Equal([]byte("bond")))
+		Expect(busy.closeCount()).To(Equal(int32(1)), "Should close the instance once the transaction returns")
+	})
+
+	It("should close every instance when none are running", func() {
+		wasmGuest.Close()
+
+		for _, instance := range instances {
+			Expect(instance.closeCount()).To(Equal(int32(1)))
+		}
+	})
+})
@@ -0,0 +1,543 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	packageMetadataFile = "metadata.json"
+	packageCodeFile     = "code.tar.gz"
+	packageManifestFile = "manifest.json"
+
+	ociLayoutFile = "oci-layout"
+	ociIndexFile  = "index.json"
+
+	// WasmPackageType is the chaincode type expected in package metadata
+	WasmPackageType = "wasm"
+
+	// WasmLayerMediaType is the media type of the Wasm module layer in an OCI bundle
+	WasmLayerMediaType = "application/vnd.wasm.content.layer.v1+wasm"
+	// WasmManifestMediaType is the media type of the optional operation manifest layer in an OCI bundle
+	WasmManifestMediaType = "application/vnd.wasm.manifest.v1+json"
+
+	// MaxPackageSize is the maximum uncompressed size of a tar.gz chaincode
+	// package, of the code.tar.gz file inside it, and of each OCI blob, so
+	// that a package cannot fill the available memory
+	MaxPackageSize = 256 << 20
+)
+
+var (
+	wasmMagic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+	gzipMagic   = []byte{0x1f, 0x8b}
+)
+
+// PackageMetadata describes a Wasm chaincode package, i.e. the metadata.json
+// file in a chaincode package or the config blob in an OCI bundle
+type PackageMetadata struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
+// PackageManifest optionally lists the waPC operations exported by the Wasm
+// module, the transactions it implements, and the transactions which must not
+// write to the ledger. Read-only transactions can be listed by name, or using
+// a pattern such as Query*
+type PackageManifest struct {
+	Name                 string   `json:"name,omitempty"`
+	Version              string   `json:"version,omitempty"`
+	Operations           []string `json:"operations"`
+	Transactions         []string `json:"transactions,omitempty"`
+	ReadOnlyTransactions []string `json:"readOnlyTransactions,omitempty"`
+}
+
+// WasmPackage contains a validated Wasm module and the package details it was
+// loaded with
+type WasmPackage struct {
+	Path     string
+	Metadata *PackageMetadata
+	Manifest *PackageManifest
+	Module   []byte
+	Hash     string
+}
+
+// LoadWasmPackage loads a Wasm module from a tar.gz chaincode package, an OCI
+// bundle directory, or a plain .wasm file, and validates it
+func LoadWasmPackage(packagePath string) (*WasmPackage, error) {
+	if packagePath == "" {
+		return nil, fmt.Errorf("No Wasm package specified")
+	}
+
+	info, err := os.Stat(packagePath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load Wasm package %s: %s", packagePath, err.Error())
+	}
+
+	var pkg *WasmPackage
+	if info.IsDir() {
+		logger.Info("Loading OCI bundle", zap.String("path", packagePath))
+		pkg, err = loadOCIBundle(packagePath)
+	} else {
+		var data []byte
+		data, err = ioutil.ReadFile(packagePath)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load Wasm package %s: %s", packagePath, err.Error())
+		}
+
+		if bytes.HasPrefix(data, gzipMagic) {
+			logger.Info("Loading chaincode package", zap.String("path", packagePath))
+			pkg, err = loadChaincodePackage(data)
+		} else {
+			logger.Info("Loading Wasm file", zap.String("path", packagePath))
+			pkg = &WasmPackage{Module: data}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load Wasm package %s: %s", packagePath, err.Error())
+	}
+
+	pkg.Path = packagePath
+
+	err = pkg.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Wasm package %s: %s", packagePath, err.Error())
+	}
+
+	logger.Info("Loaded Wasm module", zap.String("hash", "sha256:"+pkg.Hash))
+	return pkg, nil
+}
+
+func (pkg *WasmPackage) validate() error {
+	if pkg.Metadata != nil && pkg.Metadata.Type != "" && !strings.EqualFold(pkg.Metadata.Type, WasmPackageType) {
+		return fmt.Errorf("Unexpected chaincode type %s", pkg.Metadata.Type)
+	}
+
+	if len(pkg.Module) < len(wasmMagic)+len(wasmVersion) {
+		return fmt.Errorf("Wasm module is too short")
+	}
+
+	if !bytes.Equal(pkg.Module[0:4], wasmMagic) {
+		return fmt.Errorf("Wasm module does not start with the Wasm magic number")
+	}
+
+	if !bytes.Equal(pkg.Module[4:8], wasmVersion) {
+		return fmt.Errorf("Unsupported Wasm binary version %x", pkg.Module[4:8])
+	}
+
+	if pkg.Manifest != nil {
+		if len(pkg.Manifest.Operations) == 0 {
+			return fmt.Errorf("Manifest does not list any operations")
+		}
+
+		if !pkg.Manifest.HasOperation("InvokeTransaction") {
+			return fmt.Errorf("Manifest does not list the InvokeTransaction operation")
+		}
+
+		for _, pattern := range pkg.Manifest.ReadOnlyTransactions {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("Manifest contains an invalid read-only transaction pattern %s", pattern)
+			}
+		}
+	}
+
+	hash := sha256.Sum256(pkg.Module)
+	pkg.Hash = hex.EncodeToString(hash[:])
+
+	return nil
+}
+
+// Name returns the name of the Wasm module from the package manifest or
+// metadata, or the package file name if neither are available
+func (pkg *WasmPackage) Name() string {
+	if pkg.Manifest != nil && pkg.Manifest.Name != "" {
+		return pkg.Manifest.Name
+	}
+
+	if pkg.Metadata != nil && pkg.Metadata.Label != "" {
+		return pkg.Metadata.Label
+	}
+
+	name := filepath.Base(pkg.Path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// HasOperation returns true if the manifest lists the specified operation
+func (manifest *PackageManifest) HasOperation(operation string) bool {
+	for _, op := range manifest.Operations {
+		if op == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasTransaction returns true if the manifest lists the named transaction,
+// either as a transaction or by name as a read-only transaction
+func (manifest *PackageManifest) HasTransaction(function string) bool {
+	if manifest == nil {
+		return false
+	}
+
+	for _, name := range manifest.Transactions {
+		if name == function {
+			return true
+		}
+	}
+
+	for _, name := range manifest.ReadOnlyTransactions {
+		if name == function {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsReadOnly returns true if the manifest declares that the named transaction
+// must not write to the ledger
+func (manifest *PackageManifest) IsReadOnly(function string) bool {
+	if manifest == nil {
+		return false
+	}
+
+	for _, pattern := range manifest.ReadOnlyTransactions {
+		if matched, _ := path.Match(pattern, function); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadChaincodePackage unpacks a Fabric style chaincode package, which
+// contains metadata.json and code.tar.gz files. The Wasm module can be either
+// in code.tar.gz or at the top level of the package, but there must only be
+// one. The optional manifest.json is expected either in the same directory as
+// the Wasm module or at the top level of the package
+func loadChaincodePackage(data []byte) (*WasmPackage, error) {
+	files, err := untar(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &WasmPackage{}
+
+	if metadataBytes, ok := files[packageMetadataFile]; ok {
+		pkg.Metadata = &PackageMetadata{}
+		err = json.Unmarshal(metadataBytes, pkg.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", packageMetadataFile, err.Error())
+		}
+	}
+
+	archives := []packageArchive{{files: files}}
+	if codeBytes, ok := files[packageCodeFile]; ok {
+		codeFiles, err := untar(codeBytes)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", packageCodeFile, err.Error())
+		}
+
+		archives = append(archives, packageArchive{location: packageCodeFile, files: codeFiles})
+	}
+
+	var moduleArchive packageArchive
+	var modulePath string
+	var modules []string
+	for _, archive := range archives {
+		for name, content := range archive.files {
+			if path.Ext(name) != ".wasm" {
+				continue
+			}
+
+			moduleArchive = archive
+			modulePath = name
+			modules = append(modules, archive.describe(name))
+			pkg.Module = content
+		}
+	}
+
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("Package does not contain a Wasm module")
+	}
+
+	if len(modules) > 1 {
+		sort.Strings(modules)
+		return nil, fmt.Errorf("Package contains more than one Wasm module: %s", strings.Join(modules, ", "))
+	}
+
+	// The manifest can be next to the Wasm module, or at the top level of
+	// the package
+	var manifests []string
+	var manifestBytes []byte
+	manifestPath := path.Join(path.Dir(modulePath), packageManifestFile)
+	if content, ok := moduleArchive.files[manifestPath]; ok {
+		manifests = append(manifests, moduleArchive.describe(manifestPath))
+		manifestBytes = content
+	}
+	if content, ok := files[packageManifestFile]; ok && (moduleArchive.location != "" || manifestPath != packageManifestFile) {
+		manifests = append(manifests, packageManifestFile)
+		manifestBytes = content
+	}
+
+	if len(manifests) > 1 {
+		return nil, fmt.Errorf("Package contains more than one manifest: %s", strings.Join(manifests, ", "))
+	}
+
+	if len(manifests) == 1 {
+		pkg.Manifest = &PackageManifest{}
+		err = json.Unmarshal(manifestBytes, pkg.Manifest)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", manifests[0], err.Error())
+		}
+	}
+
+	return pkg, nil
+}
+
+// packageArchive is the contents of a chaincode package, or of the
+// code.tar.gz file inside it
+type packageArchive struct {
+	location string
+	files    map[string][]byte
+}
+
+// describe returns the location of a file in the chaincode package
+func (archive packageArchive) describe(name string) string {
+	if archive.location == "" {
+		return name
+	}
+
+	return archive.location + ":" + name
+}
+
+// untar returns the regular files in a tar.gz archive, keyed by their cleaned
+// relative path, and fails if the archive expands to more than MaxPackageSize
+func untar(data []byte) (map[string][]byte, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	limitReader := &io.LimitedReader{R: gzipReader, N: MaxPackageSize + 1}
+	tooLarge := func(err error) error {
+		if limitReader.N <= 0 {
+			return fmt.Errorf("Archive exceeds the maximum size of %d bytes", MaxPackageSize)
+		}
+
+		return err
+	}
+
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(limitReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, tooLarge(err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("Invalid file path %s", header.Name)
+		}
+
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("Duplicate file %s", header.Name)
+		}
+
+		if header.Size > MaxPackageSize {
+			return nil, fmt.Errorf("Archive exceeds the maximum size of %d bytes", MaxPackageSize)
+		}
+
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, tooLarge(err)
+		}
+		files[name] = content
+	}
+
+	if limitReader.N <= 0 {
+		return nil, tooLarge(nil)
+	}
+
+	return files, nil
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// loadOCIBundle loads a Wasm module from an OCI image layout directory. The
+// config blob is used as the package metadata and each blob is checked
+// against its digest
+func loadOCIBundle(dir string) (*WasmPackage, error) {
+	if _, err := os.Stat(filepath.Join(dir, ociLayoutFile)); err != nil {
+		return nil, fmt.Errorf("Directory is not an OCI bundle: %s", err.Error())
+	}
+
+	indexBytes, err := ioutil.ReadFile(filepath.Join(dir, ociIndexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	index := &ociIndex{}
+	err = json.Unmarshal(indexBytes, index)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s: %s", ociIndexFile, err.Error())
+	}
+
+	if len(index.Manifests) != 1 {
+		return nil, fmt.Errorf("Expected one manifest in %s but found %d", ociIndexFile, len(index.Manifests))
+	}
+
+	manifestBytes, err := readOCIBlob(dir, index.Manifests[0])
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ociManifest{}
+	err = json.Unmarshal(manifestBytes, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid image manifest: %s", err.Error())
+	}
+
+	pkg := &WasmPackage{}
+
+	if manifest.Config.Digest != "" {
+		configBytes, err := readOCIBlob(dir, manifest.Config)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg.Metadata = &PackageMetadata{}
+		err = json.Unmarshal(configBytes, pkg.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid config blob: %s", err.Error())
+		}
+	}
+
+	for _, layer := range manifest.Layers {
+		switch layer.MediaType {
+		case WasmLayerMediaType:
+			if pkg.Module != nil {
+				return nil, fmt.Errorf("Bundle contains more than one Wasm module")
+			}
+
+			pkg.Module, err = readOCIBlob(dir, layer)
+			if err != nil {
+				return nil, err
+			}
+		case WasmManifestMediaType:
+			layerBytes, err := readOCIBlob(dir, layer)
+			if err != nil {
+				return nil, err
+			}
+
+			pkg.Manifest = &PackageManifest{}
+			err = json.Unmarshal(layerBytes, pkg.Manifest)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid manifest layer: %s", err.Error())
+			}
+		}
+	}
+
+	if pkg.Module == nil {
+		return nil, fmt.Errorf("Bundle does not contain a %s layer", WasmLayerMediaType)
+	}
+
+	return pkg, nil
+}
+
+func readOCIBlob(dir string, descriptor ociDescriptor) ([]byte, error) {
+	parts := strings.SplitN(descriptor.Digest, ":", 2)
+	if len(parts) != 2 || parts[0] != "sha256" || !validDigest(parts[1]) {
+		return nil, fmt.Errorf("Unsupported digest %s", descriptor.Digest)
+	}
+
+	if descriptor.Size > MaxPackageSize {
+		return nil, fmt.Errorf("Blob %s exceeds the maximum size of %d bytes", descriptor.Digest, MaxPackageSize)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "blobs", parts[0], parts[1]))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// Blobs are read through a limited reader, so that a blob which is larger
+	// than expected cannot fill the available memory before its digest is
+	// checked
+	limit := int64(MaxPackageSize)
+	if descriptor.Size != 0 {
+		limit = descriptor.Size
+	}
+
+	blob, err := ioutil.ReadAll(&io.LimitedReader{R: file, N: limit + 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(blob)) > limit {
+		if descriptor.Size != 0 {
+			return nil, fmt.Errorf("Blob %s is larger than its expected size %d", descriptor.Digest, descriptor.Size)
+		}
+		return nil, fmt.Errorf("Blob %s exceeds the maximum size of %d bytes", descriptor.Digest, MaxPackageSize)
+	}
+
+	if descriptor.Size != 0 && int64(len(blob)) != descriptor.Size {
+		return nil, fmt.Errorf("Blob %s has size %d but expected %d", descriptor.Digest, len(blob), descriptor.Size)
+	}
+
+	hash := sha256.Sum256(blob)
+	if hex.EncodeToString(hash[:]) != parts[1] {
+		return nil, fmt.Errorf("Blob %s does not match its digest", descriptor.Digest)
+	}
+
+	return blob, nil
+}
+
+// validDigest returns true if the encoded part of a digest is a hex encoded
+// SHA-256 hash, so that it is safe to use as a blob file name
+func validDigest(encoded string) bool {
+	hash, err := hex.DecodeString(encoded)
+	return err == nil && len(hash) == sha256.Size
+}
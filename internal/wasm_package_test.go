@@ -0,0 +1,395 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
+)
+
+var testModule = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+func tarGz(files map[string][]byte) []byte {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for name, content := range files {
+		header := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}
+		Expect(tarWriter.WriteHeader(header)).To(Succeed())
+		_, err := tarWriter.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+
+	return buffer.Bytes()
+}
+
+func writeBlob(dir string, mediaType string, content []byte) map[string]interface{} {
+	hash := sha256.Sum256(content)
+	digest := hex.EncodeToString(hash[:])
+	Expect(ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", digest), content, 0644)).To(Succeed())
+
+	return map[string]interface{}{
+		"mediaType": mediaType,
+		"digest":    "sha256:" + digest,
+		"size":      len(content),
+	}
+}
+
+var _ = Describe("WasmPackage", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "wasmpackage")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tempDir)
+	})
+
+	Describe("LoadWasmPackage", func() {
+		It("should fail if no package is specified", func() {
+			_, err := internal.LoadWasmPackage("")
+			Expect(err).To(MatchError("No Wasm package specified"))
+		})
+
+		It("should fail if the package does not exist", func() {
+			_, err := internal.LoadWasmPackage(filepath.Join(tempDir, "missing.wasm"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("With a plain Wasm file", func() {
+			It("should load the module", func() {
+				wasmFile := filepath.Join(tempDir, "contract.wasm")
+				Expect(ioutil.WriteFile(wasmFile, testModule, 0644)).To(Succeed())
+
+				pkg, err := internal.LoadWasmPackage(wasmFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Module).To(Equal(testModule))
+				Expect(pkg.Manifest).To(BeNil())
+
+				hash := sha256.Sum256(testModule)
+				Expect(pkg.Hash).To(Equal(hex.EncodeToString(hash[:])))
+			})
+
+			It("should reject a file which is not a Wasm module", func() {
+				wasmFile := filepath.Join(tempDir, "contract.wasm")
+				Expect(ioutil.WriteFile(wasmFile, []byte("not really wasm"), 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(wasmFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Invalid Wasm package %s: Wasm module does not start with the Wasm magic number", wasmFile)))
+			})
+		})
+
+		Context("With a chaincode package", func() {
+			var packageFile string
+
+			BeforeEach(func() {
+				packageFile = filepath.Join(tempDir, "wasmcc.tgz")
+			})
+
+			It("should load the module and manifest from code.tar.gz", func() {
+				code := tarGz(map[string][]byte{
+					"src/fabcar.wasm":   testModule,
+					"src/manifest.json": []byte(`{"name":"fabcar","operations":["InvokeTransaction"]}`),
+				})
+				data := tarGz(map[string][]byte{
+					"metadata.json": []byte(`{"type":"wasm","label":"fabcar"}`),
+					"code.tar.gz":   code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				pkg, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Module).To(Equal(testModule))
+				Expect(pkg.Metadata.Label).To(Equal("fabcar"))
+				Expect(pkg.Manifest.Name).To(Equal("fabcar"))
+				Expect(pkg.Manifest.Operations).To(ConsistOf("InvokeTransaction"))
+			})
+
+			It("should load the module from code.tar.gz and the manifest from the top level", func() {
+				code := tarGz(map[string][]byte{
+					"src/fabcar.wasm": testModule,
+				})
+				data := tarGz(map[string][]byte{
+					"metadata.json": []byte(`{"type":"wasm","label":"fabcar"}`),
+					"manifest.json": []byte(`{"name":"fabcar","operations":["InvokeTransaction"]}`),
+					"code.tar.gz":   code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				pkg, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Module).To(Equal(testModule))
+				Expect(pkg.Manifest.Name).To(Equal("fabcar"))
+			})
+
+			It("should load the module from the top level alongside code.tar.gz", func() {
+				code := tarGz(map[string][]byte{
+					"src/README.md": []byte("fabcar"),
+				})
+				data := tarGz(map[string][]byte{
+					"metadata.json": []byte(`{"type":"wasm","label":"fabcar"}`),
+					"fabcar.wasm":   testModule,
+					"code.tar.gz":   code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				pkg, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Module).To(Equal(testModule))
+			})
+
+			It("should reject a package with Wasm modules at both levels", func() {
+				code := tarGz(map[string][]byte{
+					"src/fabcar.wasm": testModule,
+				})
+				data := tarGz(map[string][]byte{
+					"fabcar.wasm": testModule,
+					"code.tar.gz": code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Package contains more than one Wasm module: code.tar.gz:src/fabcar.wasm, fabcar.wasm", packageFile)))
+			})
+
+			It("should reject a package with manifests at both levels", func() {
+				code := tarGz(map[string][]byte{
+					"src/fabcar.wasm":   testModule,
+					"src/manifest.json": []byte(`{"operations":["InvokeTransaction"]}`),
+				})
+				data := tarGz(map[string][]byte{
+					"manifest.json": []byte(`{"operations":["InvokeTransaction"]}`),
+					"code.tar.gz":   code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Package contains more than one manifest: code.tar.gz:src/manifest.json, manifest.json", packageFile)))
+			})
+
+			It("should allow files with the same name in different directories", func() {
+				code := tarGz(map[string][]byte{
+					"META-INF/manifest.json": []byte(`{"operations":["GetMetadata"]}`),
+					"src/fabcar.wasm":        testModule,
+					"src/manifest.json":      []byte(`{"name":"fabcar","operations":["InvokeTransaction"]}`),
+				})
+				data := tarGz(map[string][]byte{
+					"code.tar.gz": code,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				pkg, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Manifest.Name).To(Equal("fabcar"))
+			})
+
+			It("should reject a package which expands beyond the maximum size", func() {
+				// Only the header is needed to claim an oversized file
+				buffer := &bytes.Buffer{}
+				gzipWriter := gzip.NewWriter(buffer)
+				tarWriter := tar.NewWriter(gzipWriter)
+				header := &tar.Header{Name: "fabcar.wasm", Mode: 0644, Size: internal.MaxPackageSize + 1, Typeflag: tar.TypeReg}
+				Expect(tarWriter.WriteHeader(header)).To(Succeed())
+				_, err := tarWriter.Write(testModule)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tarWriter.Flush()).NotTo(Succeed())
+				Expect(gzipWriter.Close()).To(Succeed())
+				data := buffer.Bytes()
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err = internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Archive exceeds the maximum size of %d bytes", packageFile, internal.MaxPackageSize)))
+			})
+
+			It("should reject a package with the wrong chaincode type", func() {
+				data := tarGz(map[string][]byte{
+					"metadata.json": []byte(`{"type":"golang","label":"fabcar"}`),
+					"fabcar.wasm":   testModule,
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Invalid Wasm package %s: Unexpected chaincode type golang", packageFile)))
+			})
+
+			It("should reject a package without a Wasm module", func() {
+				data := tarGz(map[string][]byte{
+					"metadata.json": []byte(`{"type":"wasm","label":"fabcar"}`),
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Package does not contain a Wasm module", packageFile)))
+			})
+
+			It("should reject a manifest without the InvokeTransaction operation", func() {
+				data := tarGz(map[string][]byte{
+					"fabcar.wasm":   testModule,
+					"manifest.json": []byte(`{"operations":["GetMetadata"]}`),
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Invalid Wasm package %s: Manifest does not list the InvokeTransaction operation", packageFile)))
+			})
+
+			It("should reject a manifest with an invalid read-only transaction pattern", func() {
+				data := tarGz(map[string][]byte{
+					"fabcar.wasm":   testModule,
+					"manifest.json": []byte(`{"operations":["InvokeTransaction"],"readOnlyTransactions":["Query["]}`),
+				})
+				Expect(ioutil.WriteFile(packageFile, data, 0644)).To(Succeed())
+
+				_, err := internal.LoadWasmPackage(packageFile)
+				Expect(err).To(MatchError(fmt.Sprintf("Invalid Wasm package %s: Manifest contains an invalid read-only transaction pattern Query[", packageFile)))
+			})
+		})
+
+		Context("With an OCI bundle", func() {
+			var layers []map[string]interface{}
+
+			BeforeEach(func() {
+				Expect(os.MkdirAll(filepath.Join(tempDir, "blobs", "sha256"), 0755)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(tempDir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644)).To(Succeed())
+
+				layers = []map[string]interface{}{
+					writeBlob(tempDir, internal.WasmLayerMediaType, testModule),
+					writeBlob(tempDir, internal.WasmManifestMediaType, []byte(`{"operations":["InvokeTransaction"]}`)),
+				}
+			})
+
+			JustBeforeEach(func() {
+				config := writeBlob(tempDir, "application/vnd.wasm.config.v1+json", []byte(`{"type":"wasm","label":"fabcar"}`))
+				manifestBytes, _ := json.Marshal(map[string]interface{}{
+					"schemaVersion": 2,
+					"config":        config,
+					"layers":        layers,
+				})
+				manifest := writeBlob(tempDir, "application/vnd.oci.image.manifest.v1+json", manifestBytes)
+				indexBytes, _ := json.Marshal(map[string]interface{}{
+					"schemaVersion": 2,
+					"manifests":     []map[string]interface{}{manifest},
+				})
+				Expect(ioutil.WriteFile(filepath.Join(tempDir, "index.json"), indexBytes, 0644)).To(Succeed())
+			})
+
+			It("should load the module, metadata and manifest", func() {
+				pkg, err := internal.LoadWasmPackage(tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pkg.Module).To(Equal(testModule))
+				Expect(pkg.Metadata.Label).To(Equal("fabcar"))
+				Expect(pkg.Manifest.Operations).To(ConsistOf("InvokeTransaction"))
+			})
+
+			Context("When a blob has been modified", func() {
+				BeforeEach(func() {
+					digest := layers[0]["digest"].(string)[len("sha256:"):]
+					Expect(ioutil.WriteFile(filepath.Join(tempDir, "blobs", "sha256", digest), []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x01}, 0644)).To(Succeed())
+				})
+
+				It("should fail digest validation", func() {
+					_, err := internal.LoadWasmPackage(tempDir)
+					Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Blob %s does not match its digest", tempDir, layers[0]["digest"])))
+				})
+			})
+
+			Context("When a digest is not a hex encoded hash", func() {
+				BeforeEach(func() {
+					// A digest of the right length which escapes the blobs directory
+					layers[0]["digest"] = "sha256:" + strings.Repeat("../", 21) + "x"
+				})
+
+				It("should fail without reading outside the bundle", func() {
+					_, err := internal.LoadWasmPackage(tempDir)
+					Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Unsupported digest %s", tempDir, layers[0]["digest"])))
+				})
+			})
+
+			Context("When a blob is larger than the maximum size", func() {
+				BeforeEach(func() {
+					layers[0]["size"] = internal.MaxPackageSize + 1
+				})
+
+				It("should fail without reading the blob", func() {
+					_, err := internal.LoadWasmPackage(tempDir)
+					Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Blob %s exceeds the maximum size of %d bytes", tempDir, layers[0]["digest"], internal.MaxPackageSize)))
+				})
+			})
+
+			Context("When a blob is larger than its descriptor", func() {
+				BeforeEach(func() {
+					digest := layers[0]["digest"].(string)[len("sha256:"):]
+					Expect(os.Truncate(filepath.Join(tempDir, "blobs", "sha256", digest), internal.MaxPackageSize*2)).To(Succeed())
+				})
+
+				It("should only read up to the expected size", func() {
+					_, err := internal.LoadWasmPackage(tempDir)
+					Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Blob %s is larger than its expected size %d", tempDir, layers[0]["digest"], len(testModule))))
+				})
+			})
+
+			Context("Without a Wasm layer", func() {
+				BeforeEach(func() {
+					layers = layers[1:]
+				})
+
+				It("should fail", func() {
+					_, err := internal.LoadWasmPackage(tempDir)
+					Expect(err).To(MatchError(fmt.Sprintf("Unable to load Wasm package %s: Bundle does not contain a %s layer", tempDir, internal.WasmLayerMediaType)))
+				})
+			})
+		})
+	})
+
+	Describe("PackageManifest", func() {
+		It("should match read-only transactions by name or pattern", func() {
+			manifest := &internal.PackageManifest{ReadOnlyTransactions: []string{"ReadCar", "Query*"}}
+			Expect(manifest.IsReadOnly("ReadCar")).To(BeTrue())
+			Expect(manifest.IsReadOnly("QueryAllCars")).To(BeTrue())
+			Expect(manifest.IsReadOnly("CreateCar")).To(BeFalse())
+		})
+
+		It("should not declare any read-only transactions without a manifest", func() {
+			var manifest *internal.PackageManifest
+			Expect(manifest.IsReadOnly("QueryAllCars")).To(BeFalse())
+		})
+
+		It("should list transactions by name only", func() {
+			manifest := &internal.PackageManifest{
+				Transactions:         []string{"CreateCar"},
+				ReadOnlyTransactions: []string{"ReadCar", "Query*"},
+			}
+			Expect(manifest.HasTransaction("CreateCar")).To(BeTrue())
+			Expect(manifest.HasTransaction("ReadCar")).To(BeTrue())
+			Expect(manifest.HasTransaction("QueryAllCars")).To(BeFalse())
+			Expect(manifest.HasTransaction("DeleteCar")).To(BeFalse())
+		})
+	})
+})
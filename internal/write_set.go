@@ -0,0 +1,325 @@
+// Copyright the Hyperledger Fabric contributors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledgendary/fabric-chaincode-wasm/hostapi"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// CapabilityReadYourWrites is the capability for ledger reads to reflect the
+// pending writes and deletes in the current transaction
+const CapabilityReadYourWrites = "ReadYourWrites"
+
+type writeKey struct {
+	collection, key string
+}
+
+type pendingWrite struct {
+	value   []byte
+	deleted bool
+}
+
+// WriteSet keeps track of the pending writes and deletes in a transaction, so
+// that later reads in the same transaction can reflect them
+type WriteSet struct {
+	sync.Mutex
+	writes map[writeKey]*pendingWrite
+	order  []writeKey
+	closed bool
+}
+
+// NewWriteSet returns a new, empty write set
+func NewWriteSet() *WriteSet {
+	return &WriteSet{
+		writes: make(map[writeKey]*pendingWrite),
+	}
+}
+
+func (ws *WriteSet) record(collection string, key string, write *pendingWrite) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	if ws.closed {
+		return
+	}
+
+	wk := writeKey{collection, key}
+	if _, ok := ws.writes[wk]; !ok {
+		ws.order = append(ws.order, wk)
+	}
+	ws.writes[wk] = write
+}
+
+// Put records a pending write
+func (ws *WriteSet) Put(collection string, key string, value []byte) {
+	ws.record(collection, key, &pendingWrite{value: value})
+}
+
+// Delete records a pending delete
+func (ws *WriteSet) Delete(collection string, key string) {
+	ws.record(collection, key, &pendingWrite{deleted: true})
+}
+
+// Get returns the pending value for a key, which is nil if the key has been
+// deleted, and true if the key has been written or deleted in the transaction
+func (ws *WriteSet) Get(collection string, key string) ([]byte, bool) {
+	ws.Lock()
+	defer ws.Unlock()
+
+	write, ok := ws.writes[writeKey{collection, key}]
+	if !ok {
+		return nil, false
+	}
+
+	return write.value, true
+}
+
+// Entries returns the pending writes and deletes, in the order the keys were
+// first written
+func (ws *WriteSet) Entries() []*hostapi.WriteSetEntry {
+	ws.Lock()
+	defer ws.Unlock()
+
+	entries := make([]*hostapi.WriteSetEntry, 0, len(ws.order))
+	for _, wk := range ws.order {
+		write := ws.writes[wk]
+		entries = append(entries, &hostapi.WriteSetEntry{
+			Collection: wk.collection,
+			Key:        wk.key,
+			Value:      write.value,
+			Deleted:    write.deleted,
+		})
+	}
+
+	return entries
+}
+
+// Close discards the pending writes once the transaction has ended. Later
+// writes are not recorded
+func (ws *WriteSet) Close() error {
+	ws.Lock()
+	defer ws.Unlock()
+
+	ws.closed = true
+	ws.writes = make(map[writeKey]*pendingWrite)
+	ws.order = nil
+
+	return nil
+}
+
+// ledgerView provides the ledger operations used by the FabricProxy for a
+// transaction. If the transaction has a write set, reads reflect pending
+// writes and deletes, otherwise operations go straight to the stub
+type ledgerView struct {
+	stub   shim.ChaincodeStubInterface
+	writes *WriteSet
+	mspID  string
+}
+
+// collection returns the private data collection name for a collection in a
+// request, resolving symbolic implicit collection names
+func (view *ledgerView) collection(collection string) (string, error) {
+	return resolveCollection(collection, view.mspID)
+}
+
+func (view *ledgerView) GetState(key string) ([]byte, error) {
+	return view.GetPrivateData("", key)
+}
+
+func (view *ledgerView) PutState(key string, value []byte) error {
+	return view.PutPrivateData("", key, value)
+}
+
+func (view *ledgerView) DelState(key string) error {
+	return view.DelPrivateData("", key)
+}
+
+// GetMultipleStates returns the values of several world state keys, like
+// GetMultiplePrivateData
+func (view *ledgerView) GetMultipleStates(keys []string) ([][]byte, error) {
+	return view.GetMultiplePrivateData("", keys)
+}
+
+func (view *ledgerView) GetPrivateData(collection string, key string) ([]byte, error) {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.writes != nil {
+		if value, ok := view.writes.Get(collection, key); ok {
+			return value, nil
+		}
+	}
+
+	if collection == "" {
+		return view.stub.GetState(key)
+	}
+
+	return view.stub.GetPrivateData(collection, key)
+}
+
+func (view *ledgerView) PutPrivateData(collection string, key string, value []byte) error {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return err
+	}
+
+	if collection == "" {
+		err = view.stub.PutState(key, value)
+	} else {
+		err = view.stub.PutPrivateData(collection, key, value)
+	}
+
+	if err == nil && view.writes != nil {
+		view.writes.Put(collection, key, value)
+	}
+
+	return err
+}
+
+func (view *ledgerView) DelPrivateData(collection string, key string) error {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return err
+	}
+
+	if collection == "" {
+		err = view.stub.DelState(key)
+	} else {
+		err = view.stub.DelPrivateData(collection, key)
+	}
+
+	if err == nil && view.writes != nil {
+		view.writes.Delete(collection, key)
+	}
+
+	return err
+}
+
+// GetPrivateDataHash returns the hash of a private data value from the peer,
+// which does not reflect pending writes
+func (view *ledgerView) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return view.stub.GetPrivateDataHash(collection, key)
+}
+
+// GetPrivateDataByRange returns an iterator over a range of keys from the
+// peer, which does not reflect pending writes
+func (view *ledgerView) GetPrivateDataByRange(collection string, startKey string, endKey string) (shim.StateQueryIteratorInterface, error) {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection == "" {
+		return view.stub.GetStateByRange(startKey, endKey)
+	}
+
+	return view.stub.GetPrivateDataByRange(collection, startKey, endKey)
+}
+
+// CapabilityPurgePrivateData is the capability for guests to purge private
+// data with the PurgeState host call. Purging only succeeds with a Fabric shim
+// which supports PurgePrivateData, and otherwise fails with NOT_SUPPORTED
+const CapabilityPurgePrivateData = "PurgePrivateData"
+
+// privateDataPurger is implemented by stubs for peers which support purging
+// private data
+type privateDataPurger interface {
+	PurgePrivateData(collection string, key string) error
+}
+
+// PurgePrivateData purges a private data state, and returns false if the stub
+// does not support purging private data
+func (view *ledgerView) PurgePrivateData(collection string, key string) (bool, error) {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return false, err
+	}
+
+	purger, ok := view.stub.(privateDataPurger)
+	if !ok {
+		return false, nil
+	}
+
+	err = purger.PurgePrivateData(collection, key)
+	if err == nil && view.writes != nil {
+		view.writes.Delete(collection, key)
+	}
+
+	return true, err
+}
+
+// GetMultiplePrivateData returns the values of several keys, which are nil for
+// keys with no state. Pending writes are used where available, and the other
+// keys are fetched one at a time, since the shim has no batched read and does
+// not allow concurrent requests to the peer for the same transaction
+func (view *ledgerView) GetMultiplePrivateData(collection string, keys []string) ([][]byte, error) {
+	collection, err := view.collection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, len(keys))
+
+	var fetchKeys []string
+	var fetchIndexes []int
+	for i, key := range keys {
+		if view.writes != nil {
+			if value, ok := view.writes.Get(collection, key); ok {
+				values[i] = value
+				continue
+			}
+		}
+
+		fetchKeys = append(fetchKeys, key)
+		fetchIndexes = append(fetchIndexes, i)
+	}
+
+	if len(fetchKeys) == 0 {
+		return values, nil
+	}
+
+	fetched, err := view.fetchMultiple(collection, fetchKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fetched) != len(fetchKeys) {
+		return nil, fmt.Errorf("Expected %d values but received %d", len(fetchKeys), len(fetched))
+	}
+
+	for j, i := range fetchIndexes {
+		values[i] = fetched[j]
+	}
+
+	return values, nil
+}
+
+func (view *ledgerView) fetchMultiple(collection string, keys []string) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		var err error
+		if collection == "" {
+			values[i], err = view.stub.GetState(key)
+		} else {
+			values[i], err = view.stub.GetPrivateData(collection, key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
@@ -4,64 +4,259 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
-	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/hyperledgendary/fabric-chaincode-wasm/internal"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.uber.org/zap"
 )
 
-// ChaincodeConfig is used to configure the chaincode server. See chaincode.env.example
-type ChaincodeConfig struct {
-	CCID    string
-	Address string
-	WasmCC  string
-}
+// Exit status codes
+const (
+	exitOK           = 0
+	exitServerError  = 1
+	exitConfigError  = 2
+	exitDrainTimeout = 3
+)
 
 func main() {
-	log.Printf("[host] Wasm Chaincode client-server...\n")
+	os.Exit(run())
+}
 
-	config := ChaincodeConfig{
-		CCID:    os.Getenv("CHAINCODE_ID"),
-		Address: os.Getenv("CHAINCODE_SERVER_ADDRESS"),
-		WasmCC:  os.Getenv("CHAINCODE_WASM_FILE"),
+// run starts the Wasm chaincode and returns the exit status once it has
+// stopped, after any deferred cleanup has completed
+func run() int {
+	config, err := internal.LoadConfig(os.Args[0], os.Args[1:], os.LookupEnv)
+	if err == flag.ErrHelp {
+		return exitOK
+	}
+	if err == nil {
+		err = config.Validate()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return exitConfigError
+	}
+
+	logger, err := internal.NewLogger(config.Logging)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating logger: %s\n", err.Error())
+		return exitConfigError
+	}
+	defer logger.Sync()
+	internal.SetLogger(logger)
+
+	logger.Info("Wasm Chaincode client-server...", zap.Any("config", config.Summary()))
+
+	var tlsProps shim.TLSProperties
+	if len(config.Address) > 0 {
+		tlsProps, err = internal.LoadTLSProperties(config.TLS)
+		if err != nil {
+			logger.Error("Invalid TLS configuration", zap.Error(err))
+			return exitConfigError
+		}
+	}
+
+	collections, err := internal.LoadCollectionConfigs(config.Collections)
+	if err != nil {
+		logger.Error("Invalid collections configuration", zap.Error(err))
+		return exitConfigError
 	}
-	log.Printf("[host] CCID: %s\n", config.CCID)
-	log.Printf("[host] Address: %s\n", config.Address)
-	log.Printf("[host] WasmCC: %s\n", config.WasmCC)
 
 	contextStore := internal.NewContextStore()
+	health := internal.NewHealth(contextStore, config.Health.WedgedTimeout)
+
+	// The metrics and health endpoints share a server if they use the same address
+	httpServers := make(map[string]*http.ServeMux)
+	serveMux := func(address string) *http.ServeMux {
+		if _, ok := httpServers[address]; !ok {
+			httpServers[address] = http.NewServeMux()
+		}
+		return httpServers[address]
+	}
+
+	if len(config.Metrics.Address) > 0 {
+		serveMux(config.Metrics.Address).Handle("/metrics", internal.MetricsHandler())
+	}
+
+	if len(config.Health.Address) > 0 {
+		healthHandler := health.Handler()
+		for _, path := range []string{"/livez", "/readyz", "/healthz", "/status"} {
+			serveMux(config.Health.Address).Handle(path, healthHandler)
+		}
+	}
+
+	for address, mux := range httpServers {
+		err = startHTTPServer(logger, address, mux)
+		if err != nil {
+			logger.Error("Error starting HTTP server", zap.String("address", address), zap.Error(err))
+			return exitServerError
+		}
+		logger.Info("HTTP server listening", zap.String("address", address))
+	}
+
+	wasmPackage, err := internal.LoadWasmPackage(config.Wasm.Path())
+	if err != nil {
+		logger.Error("Invalid Wasm package", zap.Error(err))
+		return exitConfigError
+	}
+	health.SetPackage(wasmPackage)
+
+	config.Tracing.ServiceName = wasmPackage.Name()
+	shutdownTracing, err := internal.InitTracing(config.Tracing)
+	if err != nil {
+		logger.Error("Invalid tracing configuration", zap.Error(err))
+		return exitConfigError
+	}
+	defer shutdownTracing(context.Background())
+
 	proxy := internal.NewFabricProxy(contextStore)
 
-	wasmGuest, err := internal.NewWasmGuest(config.WasmCC, proxy)
+	guestLogger, err := internal.NewGuestLogger(wasmPackage.Name(), config.GuestLogging)
+	if err != nil {
+		logger.Error("Invalid guest logging configuration", zap.Error(err))
+		return exitConfigError
+	}
+	proxy.SetGuestLogger(guestLogger)
+
+	stateHasher, err := internal.NewStateHasher(config.Hash)
+	if err != nil {
+		logger.Error("Invalid hash configuration", zap.Error(err))
+		return exitConfigError
+	}
+	proxy.SetStateHasher(stateHasher)
+	proxy.SetMSPID(config.MSPID)
+	proxy.SetCollectionConfigs(collections)
+
+	wasmGuest, err := internal.NewWasmGuest(wasmPackage, proxy, config.Pool)
 	if err != nil {
-		panic(err)
+		logger.Error("Error creating Wasm guest", zap.Error(err))
+		return exitConfigError
 	}
+	// Close only closes idle instances, so it is safe even if shutdown stops
+	// waiting for transactions which are still running in the guest
 	defer wasmGuest.Close()
+	health.SetPool(wasmGuest)
 
 	contract := internal.NewWasmContract(contextStore, wasmGuest)
+	contract.SetManifest(wasmPackage.Manifest)
+	contract.SetCapabilities(wasmGuest.Capabilities())
 
+	// Handle signals before the chaincode starts, so that a transaction can
+	// never start without being drained
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	serverErrors := make(chan error, 1)
 	if len(config.Address) > 0 {
-		log.Printf("[host] Wasm Chaincode server starting...\n")
+		logger.Info("Wasm Chaincode server starting...")
 		server := &shim.ChaincodeServer{
-			CCID:    config.CCID,
-			Address: config.Address,
-			CC:      contract,
-			TLSProps: shim.TLSProperties{
-				Disabled: true,
-			},
+			CCID:     config.CCID,
+			Address:  config.Address,
+			CC:       contract,
+			TLSProps: tlsProps,
 		}
 
-		if err := server.Start(); err != nil {
-			fmt.Printf("Error starting Wasm chaincode server: %s", err.Error())
-		}
+		done := make(chan struct{})
+		go func() {
+			if waitForListener(config.Address, done) {
+				logger.Info("Wasm Chaincode server listening", zap.String("address", config.Address))
+				health.SetListening(true)
+			}
+		}()
+
+		go func() {
+			err := server.Start()
+			close(done)
+			health.SetListening(false)
+			serverErrors <- err
+		}()
 	} else {
-		log.Printf("[host] Wasm Chaincode starting...\n")
-		if err := shim.Start(contract); err != nil {
-			fmt.Printf("Error starting Wasm chaincode: %s", err.Error())
+		logger.Info("Wasm Chaincode starting...")
+		health.SetListening(true)
+		go func() {
+			serverErrors <- shim.Start(contract)
+		}()
+	}
+
+	select {
+	case err := <-serverErrors:
+		if err != nil {
+			logger.Error("Error running Wasm chaincode", zap.Error(err))
+			return exitServerError
+		}
+
+		logger.Info("Wasm Chaincode done")
+		return exitOK
+	case sig := <-signals:
+		logger.Info("Shutting down Wasm chaincode",
+			zap.Stringer("signal", sig),
+			zap.Int("inFlight", contextStore.Count()),
+			zap.Duration("timeout", config.ShutdownTimeout),
+		)
+	}
+
+	// A second signal stops waiting for in-flight transactions
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
 		}
+	}()
+
+	err = contextStore.Drain(ctx)
+	if err != nil {
+		logger.Error("Error draining in-flight transactions", zap.Error(err))
+		return exitDrainTimeout
+	}
+
+	logger.Info("Wasm Chaincode done")
+	return exitOK
+}
+
+// startHTTPServer listens on the specified address and serves HTTP requests in
+// the background, so that address errors are reported immediately
+func startHTTPServer(logger *zap.Logger, address string, handler http.Handler) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
 	}
 
-	log.Printf("[host] Wasm Chaincode done\n")
+	go func() {
+		err := http.Serve(listener, handler)
+		if err != nil {
+			logger.Error("HTTP server stopped", zap.String("address", address), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+// waitForListener polls the chaincode server address until it accepts
+// connections, since the shim does not report when the server is listening
+func waitForListener(address string, done <-chan struct{}) bool {
+	for {
+		conn, err := net.DialTimeout("tcp", address, time.Second)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+
+		select {
+		case <-done:
+			return false
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
 }